@@ -25,6 +25,7 @@ type (
 		ExtractAdditionalInfo func(context.Context) []any
 		AddSource             bool
 		Environment           string
+		BaggageKeys           []string
 	}
 )
 
@@ -43,6 +44,7 @@ func NewSlogAdapter(opts SlogAdapterOpts) SlogAdapter {
 		ai = append(ai, "source", opts.Name)
 		ai = append(ai, opts.ExtractAdditionalInfo(ctx)...)
 		ai = append(ai, extractTraceInfo(ctx)...)
+		ai = append(ai, extractBaggage(ctx, opts.BaggageKeys)...)
 		ai = append(ai, "env", opts.Environment)
 		return ai
 	}
@@ -75,6 +77,18 @@ func extractTraceInfo(ctx context.Context) []any {
 	return nil
 }
 
+func extractBaggage(ctx context.Context, keys []string) []any {
+	if len(keys) == 0 {
+		return nil
+	}
+	attrs := trace.BaggageAttributes(ctx, keys...)
+	result := make([]any, 0, len(attrs)*2)
+	for _, a := range attrs {
+		result = append(result, a.Key, a.Value)
+	}
+	return result
+}
+
 func (l SlogAdapter) Info(ctx context.Context, msg string, args ...any) {
 	if !l.logger.Enabled(ctx, slog.LevelInfo) {
 		return
@@ -0,0 +1,24 @@
+package log
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bruno303/go-toolkit/pkg/trace"
+)
+
+func TestExtractBaggage(t *testing.T) {
+	ctx, err := trace.SetBaggage(context.Background(), "tenant_id", "acme")
+	if err != nil {
+		t.Fatalf("failed to set baggage: %v", err)
+	}
+
+	info := extractBaggage(ctx, []string{"tenant_id", "missing"})
+	if len(info) != 2 || info[0] != "tenant_id" || info[1] != "acme" {
+		t.Errorf("unexpected baggage info: %v", info)
+	}
+
+	if info := extractBaggage(ctx, nil); info != nil {
+		t.Errorf("expected no baggage info without keys, got %v", info)
+	}
+}
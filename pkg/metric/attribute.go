@@ -0,0 +1,109 @@
+package metric
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+)
+
+// AttributeSet is an immutable set of attributes converted once, to be
+// reused on hot paths with the AddSet and RecordSet methods of the instrument
+// handles. The zero value is the empty set.
+type AttributeSet struct {
+	set attribute.Set
+	// option records set, built once as boxing it allocates.
+	option otelmetric.MeasurementOption
+}
+
+// NewAttributeSet converts attrs into a reusable set. When a key is repeated
+// the last value wins.
+func NewAttributeSet(attrs ...Attribute) AttributeSet {
+	set := attribute.NewSet(toOtelAttributes(attrs)...)
+	return AttributeSet{set: set, option: otelmetric.WithAttributeSet(set)}
+}
+
+// Len returns the number of attributes in the set.
+func (s AttributeSet) Len() int {
+	return s.set.Len()
+}
+
+// measurementOption returns the option recording s limited by limiter.
+func (s AttributeSet) measurementOption(ctx context.Context, limiter *cardinalityLimiter) otelmetric.MeasurementOption {
+	if limiter == nil && s.option != nil {
+		return s.option
+	}
+	return otelmetric.WithAttributeSet(limiter.limitSet(ctx, s.set))
+}
+
+func (s AttributeSet) String() string {
+	return s.set.Encoded(attribute.DefaultEncoder())
+}
+
+func String(key string, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+func Int(key string, value int) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+func Int64(key string, value int64) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// IntegerType is satisfied by every integer width, and by the types defined
+// on them.
+type IntegerType interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Integer records value, of any integer width, as an int64. Unsigned values
+// beyond the int64 range are recorded as strings.
+func Integer[N IntegerType](key string, value N) Attribute {
+	if value < 0 {
+		return Attribute{Key: key, Value: int64(value)}
+	}
+	return Attribute{Key: key, Value: uint64(value)}
+}
+
+func Float64(key string, value float64) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+func Bool(key string, value bool) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+func StringSlice(key string, value []string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+func IntSlice(key string, value []int) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+func Int64Slice(key string, value []int64) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+func Float64Slice(key string, value []float64) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+func BoolSlice(key string, value []bool) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Duration records value as a string such as "1.5s".
+func Duration(key string, value time.Duration) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Stringer records the result of value.String().
+func Stringer(key string, value fmt.Stringer) Attribute {
+	return Attribute{Key: key, Value: value}
+}
@@ -0,0 +1,100 @@
+package metric
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestToOtelAttribute(t *testing.T) {
+	tests := []struct {
+		name     string
+		attr     Attribute
+		expected attribute.KeyValue
+	}{
+		{name: "string", attr: String("k", "v"), expected: attribute.String("k", "v")},
+		{name: "int", attr: Int("k", 1), expected: attribute.Int("k", 1)},
+		{name: "int64", attr: Int64("k", 1), expected: attribute.Int64("k", 1)},
+		{name: "float64", attr: Float64("k", 1.5), expected: attribute.Float64("k", 1.5)},
+		{name: "bool", attr: Bool("k", true), expected: attribute.Bool("k", true)},
+		{name: "string slice", attr: StringSlice("k", []string{"a"}), expected: attribute.StringSlice("k", []string{"a"})},
+		{name: "int slice", attr: IntSlice("k", []int{1}), expected: attribute.IntSlice("k", []int{1})},
+		{name: "int64 slice", attr: Int64Slice("k", []int64{1}), expected: attribute.Int64Slice("k", []int64{1})},
+		{name: "float64 slice", attr: Float64Slice("k", []float64{1}), expected: attribute.Float64Slice("k", []float64{1})},
+		{name: "bool slice", attr: BoolSlice("k", []bool{true}), expected: attribute.BoolSlice("k", []bool{true})},
+		{name: "duration", attr: Duration("k", 1500*time.Millisecond), expected: attribute.String("k", "1.5s")},
+		{name: "stringer", attr: Stringer("k", net.IPv4(10, 0, 0, 1)), expected: attribute.String("k", "10.0.0.1")},
+		{name: "int8", attr: NewAttribute("k", int8(-1)), expected: attribute.Int64("k", -1)},
+		{name: "int16", attr: NewAttribute("k", int16(-1)), expected: attribute.Int64("k", -1)},
+		{name: "int32", attr: NewAttribute("k", int32(-1)), expected: attribute.Int64("k", -1)},
+		{name: "uint", attr: NewAttribute("k", uint(1)), expected: attribute.Int64("k", 1)},
+		{name: "uint8", attr: NewAttribute("k", uint8(1)), expected: attribute.Int64("k", 1)},
+		{name: "uint16", attr: NewAttribute("k", uint16(1)), expected: attribute.Int64("k", 1)},
+		{name: "uint32", attr: NewAttribute("k", uint32(1)), expected: attribute.Int64("k", 1)},
+		{name: "uint64", attr: NewAttribute("k", uint64(1)), expected: attribute.Int64("k", 1)},
+		{name: "uint64 overflow", attr: NewAttribute("k", uint64(math.MaxUint64)), expected: attribute.String("k", "18446744073709551615")},
+		{name: "float32", attr: NewAttribute("k", float32(0.5)), expected: attribute.Float64("k", 0.5)},
+		{name: "integer int32", attr: Integer("k", int32(-1)), expected: attribute.Int64("k", -1)},
+		{name: "integer uint16", attr: Integer("k", uint16(1)), expected: attribute.Int64("k", 1)},
+		{name: "integer defined type", attr: Integer("k", time.Month(3)), expected: attribute.Int64("k", 3)},
+		{name: "integer uint64 overflow", attr: Integer("k", uint64(math.MaxUint64)), expected: attribute.String("k", "18446744073709551615")},
+		{name: "error", attr: NewAttribute("k", errors.New("boom")), expected: attribute.String("k", "boom")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := toOtelAttribute(tt.attr); got != tt.expected {
+				t.Errorf("expected %v (%v), got %v (%v)", tt.expected.Value.Emit(), tt.expected.Value.Type(), got.Value.Emit(), got.Value.Type())
+			}
+		})
+	}
+}
+
+func TestToOtelAttribute_ReportsUnsupportedTypesOnce(t *testing.T) {
+	var reported []error
+	previous := otel.GetErrorHandler()
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) { reported = append(reported, err) }))
+	t.Cleanup(func() { otel.SetErrorHandler(previous) })
+	t.Cleanup(unsupportedAttributeTypes.Clear)
+
+	type point struct{ X, Y int }
+	for range 3 {
+		got := toOtelAttribute(NewAttribute("k", point{1, 2}))
+		if got != attribute.String("k", "{1 2}") {
+			t.Errorf("expected the fmt representation, got %v", got.Value.Emit())
+		}
+	}
+	if len(reported) != 1 {
+		t.Errorf("expected a single report, got %v", reported)
+	}
+}
+
+func TestAttributeSet(t *testing.T) {
+	ctx := context.Background()
+	meter, reader := newTestOtelMeter(t)
+	counter, _ := meter.Counter("requests", InstrumentOpts{})
+	histogram, _ := meter.Histogram("duration", InstrumentOpts{})
+	set := NewAttributeSet(String("method", "GET"), Int("status", 200), String("method", "POST"))
+
+	if set.Len() != 2 {
+		t.Errorf("expected the repeated key to be replaced, got %s", set)
+	}
+	counter.AddSet(ctx, 1, set)
+	counter.Add(ctx, 1, String("method", "POST"), Int("status", 200))
+	histogram.RecordSet(ctx, 0.5, AttributeSet{})
+
+	got := collect(t, reader)
+	sum := got["requests"].(metricdata.Sum[float64])
+	if len(sum.DataPoints) != 1 || sum.DataPoints[0].Value != 2 {
+		t.Errorf("expected the set and the attributes to record the same series, got %+v", sum.DataPoints)
+	}
+	if hist := got["duration"].(metricdata.Histogram[float64]); hist.DataPoints[0].Attributes.Len() != 0 {
+		t.Errorf("expected the zero set to be empty, got %v", hist.DataPoints[0].Attributes.ToSlice())
+	}
+}
@@ -0,0 +1,18 @@
+package metric
+
+import (
+	"context"
+
+	"github.com/bruno303/go-toolkit/pkg/trace"
+)
+
+// BaggageAttributes returns the baggage members of ctx with the given keys as
+// metric attributes, so values like tenant ids can be used as dimensions.
+func BaggageAttributes(ctx context.Context, keys ...string) []Attribute {
+	baggageAttrs := trace.BaggageAttributes(ctx, keys...)
+	attrs := make([]Attribute, 0, len(baggageAttrs))
+	for _, a := range baggageAttrs {
+		attrs = append(attrs, NewAttribute(a.Key, a.Value))
+	}
+	return attrs
+}
@@ -0,0 +1,23 @@
+package metric
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bruno303/go-toolkit/pkg/trace"
+)
+
+func TestBaggageAttributes(t *testing.T) {
+	ctx, err := trace.SetBaggage(context.Background(), "tenant_id", "acme")
+	if err != nil {
+		t.Fatalf("failed to set baggage: %v", err)
+	}
+
+	attrs := BaggageAttributes(ctx, "tenant_id", "missing")
+	if len(attrs) != 1 {
+		t.Fatalf("expected 1 attribute, got %d", len(attrs))
+	}
+	if attrs[0].Key != "tenant_id" || attrs[0].Value != "acme" {
+		t.Errorf("unexpected attribute: %+v", attrs[0])
+	}
+}
@@ -0,0 +1,96 @@
+package metric
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bruno303/go-toolkit/pkg/telemetry"
+)
+
+const (
+	envMetricsExporter       = "OTEL_METRICS_EXPORTER"
+	envMetricExportInterval  = "OTEL_METRIC_EXPORT_INTERVAL"
+	envTemporalityPreference = "OTEL_EXPORTER_OTLP_METRICS_TEMPORALITY_PREFERENCE"
+	envExemplarFilter        = "OTEL_METRICS_EXEMPLAR_FILTER"
+	metricsSignal            = "METRICS"
+	metricsURLPath           = "/v1/metrics"
+)
+
+// applyEnv fills the empty fields of c with the standard OpenTelemetry
+// environment variables.
+func (c *Config) applyEnv() error {
+	if c.ApplicationName == "" {
+		c.ApplicationName = telemetry.Getenv(telemetry.EnvServiceName)
+	}
+	if c.Port == 0 {
+		if v := telemetry.Getenv(telemetry.EnvPrometheusPort); v != "" {
+			port, err := strconv.Atoi(v)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %w", telemetry.EnvPrometheusPort, err)
+			}
+			c.Port = port
+		}
+	}
+	return c.applyExporterEnv()
+}
+
+func (c *Config) applyExporterEnv() error {
+	otlp, err := telemetry.LookupOTLPEnv(metricsSignal, metricsURLPath)
+	if err != nil {
+		return err
+	}
+	c.OTLP.ApplyEnv(otlp)
+
+	if len(c.Exporters) == 0 {
+		if v := telemetry.Getenv(envMetricsExporter); v != "" {
+			if err := c.setExportersFromEnv(v, otlp.Protocol); err != nil {
+				return err
+			}
+		}
+	}
+	if c.Interval == 0 {
+		if v := telemetry.Getenv(envMetricExportInterval); v != "" {
+			ms, err := strconv.Atoi(v)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %w", envMetricExportInterval, err)
+			}
+			c.Interval = time.Duration(ms) * time.Millisecond
+		}
+	}
+	if c.Temporality == "" {
+		c.Temporality = Temporality(strings.ToLower(telemetry.Getenv(envTemporalityPreference)))
+	}
+	if c.Exemplars == "" {
+		c.Exemplars = ExemplarFilter(strings.ToLower(telemetry.Getenv(envExemplarFilter)))
+	}
+	return nil
+}
+
+// setExportersFromEnv parses OTEL_METRICS_EXPORTER.
+func (c *Config) setExportersFromEnv(v string, protocol string) error {
+	for _, name := range strings.Split(v, ",") {
+		switch name = strings.TrimSpace(name); name {
+		case "otlp":
+			switch protocol {
+			case "", telemetry.ProtocolGRPC:
+				c.Exporters = append(c.Exporters, ExporterOTLPGRPC)
+			case telemetry.ProtocolHTTPProtobuf:
+				c.Exporters = append(c.Exporters, ExporterOTLPHTTP)
+			default:
+				return fmt.Errorf("unsupported OTLP protocol %q", protocol)
+			}
+		case "prometheus":
+			c.Exporters = append(c.Exporters, ExporterPrometheus)
+		case "console":
+			c.Exporters = append(c.Exporters, ExporterStdout)
+		case "none":
+			c.Exporters = append(c.Exporters, ExporterNone)
+		case "":
+		default:
+			return fmt.Errorf("unsupported %s %q", envMetricsExporter, name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package metric
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConfig_ApplyEnv(t *testing.T) {
+	t.Setenv("OTEL_SERVICE_NAME", "from-env")
+	t.Setenv("OTEL_EXPORTER_PROMETHEUS_PORT", "9464")
+
+	cfg := Config{}
+	if err := cfg.applyEnv(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ApplicationName != "from-env" || cfg.Port != 9464 {
+		t.Errorf("unexpected config %+v", cfg)
+	}
+
+	cfg = Config{ApplicationName: "app", Port: 8080}
+	if err := cfg.applyEnv(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ApplicationName != "app" || cfg.Port != 8080 {
+		t.Errorf("expected config values to be kept, got %+v", cfg)
+	}
+
+	t.Setenv("OTEL_EXPORTER_PROMETHEUS_PORT", "http")
+	cfg = Config{}
+	if err := cfg.applyEnv(); err == nil {
+		t.Error("expected error for invalid port")
+	}
+}
+
+func TestConfig_ApplyExporterEnv(t *testing.T) {
+	t.Setenv("OTEL_METRICS_EXPORTER", "otlp, prometheus")
+	t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", "http/protobuf")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "https://collector:4318")
+	t.Setenv("OTEL_EXPORTER_OTLP_METRICS_HEADERS", "api-key=secret")
+	t.Setenv("OTEL_METRIC_EXPORT_INTERVAL", "15000")
+	t.Setenv("OTEL_EXPORTER_OTLP_METRICS_TEMPORALITY_PREFERENCE", "Delta")
+	t.Setenv("OTEL_METRICS_EXEMPLAR_FILTER", "always_off")
+
+	cfg := Config{}
+	if err := cfg.applyEnv(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(cfg.Exporters) != 2 || cfg.Exporters[0] != ExporterOTLPHTTP || cfg.Exporters[1] != ExporterPrometheus {
+		t.Errorf("unexpected exporters %v", cfg.Exporters)
+	}
+	if cfg.OTLP.Endpoint != "collector:4318" || !cfg.OTLP.TLS.Enabled || cfg.OTLP.Headers["api-key"] != "secret" {
+		t.Errorf("unexpected OTLP config %+v", cfg.OTLP)
+	}
+	if cfg.Interval != 15*time.Second {
+		t.Errorf("expected interval of 15s, got %v", cfg.Interval)
+	}
+	if cfg.Temporality != TemporalityDelta {
+		t.Errorf("expected delta temporality, got %q", cfg.Temporality)
+	}
+	if cfg.Exemplars != ExemplarFilterAlwaysOff {
+		t.Errorf("expected exemplars off, got %q", cfg.Exemplars)
+	}
+
+	t.Setenv("OTEL_METRICS_EXPORTER", "zipkin")
+	cfg = Config{}
+	if err := cfg.applyEnv(); err == nil {
+		t.Error("expected error for unsupported exporter")
+	}
+}
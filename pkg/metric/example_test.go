@@ -54,7 +54,7 @@ func Example_setupOpenTelemetry() {
 	ctx := context.Background()
 
 	// Setup OpenTelemetry metrics
-	shutdown, err := metric.SetupOTelMetrics(ctx, metric.Config{
+	provider, err := metric.SetupOTelMetrics(ctx, metric.Config{
 		ApplicationName:    "my-service",
 		ApplicationVersion: "1.0.0",
 		Environment:        "production",
@@ -65,7 +65,7 @@ func Example_setupOpenTelemetry() {
 	if err != nil {
 		panic(err)
 	}
-	defer shutdown(ctx)
+	defer provider.Shutdown(ctx)
 
 	// Now use the global meter
 	meter := metric.GetMeter()
@@ -101,10 +101,11 @@ func Example_httpMetrics() {
 		metric.NewAttribute("status", 200),
 	)
 
-	_ = meter.AddGauge(ctx,
+	_ = meter.RecordHistogram(ctx,
 		"http.server.duration",
 		"HTTP request duration",
 		"ms",
+		[]float64{5, 10, 25, 50, 100, 250, 500, 1000},
 		duration,
 		metric.NewAttribute("method", "GET"),
 		metric.NewAttribute("path", "/api/users"),
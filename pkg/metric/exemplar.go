@@ -0,0 +1,34 @@
+package metric
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
+)
+
+// ExemplarFilter selects the measurements kept as exemplars, which carry the
+// trace and span ids of the span in the recording context.
+type ExemplarFilter string
+
+const (
+	// ExemplarFilterTraceBased keeps the measurements recorded within a
+	// sampled span, the default.
+	ExemplarFilterTraceBased ExemplarFilter = "trace_based"
+	// ExemplarFilterAlwaysOn keeps any measurement, with or without a span.
+	ExemplarFilterAlwaysOn ExemplarFilter = "always_on"
+	// ExemplarFilterAlwaysOff disables the exemplars.
+	ExemplarFilterAlwaysOff ExemplarFilter = "always_off"
+)
+
+func exemplarFilter(f ExemplarFilter) (exemplar.Filter, error) {
+	switch f {
+	case "", ExemplarFilterTraceBased:
+		return exemplar.TraceBasedFilter, nil
+	case ExemplarFilterAlwaysOn:
+		return exemplar.AlwaysOnFilter, nil
+	case ExemplarFilterAlwaysOff:
+		return exemplar.AlwaysOffFilter, nil
+	default:
+		return nil, fmt.Errorf("unknown metric exemplar filter %q", f)
+	}
+}
@@ -0,0 +1,101 @@
+package metric
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bruno303/go-toolkit/pkg/trace"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func startTestSpan(t *testing.T) (context.Context, trace.TraceIDs) {
+	t.Helper()
+	provider := sdktrace.NewTracerProvider()
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+	ctx, span := provider.Tracer("test").Start(context.Background(), "operation")
+	t.Cleanup(func() { span.End() })
+	return ctx, trace.NewOtelTracerAdapter().ExtractTraceIds(ctx)
+}
+
+func newTestExemplarMeter(t *testing.T, filter exemplar.Filter) (*OtelMeter, *sdkmetric.ManualReader) {
+	t.Helper()
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader), sdkmetric.WithExemplarFilter(filter))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+	return NewOtelMeter(provider.Meter("test")), reader
+}
+
+func TestOtelMeter_Exemplars(t *testing.T) {
+	ctx, ids := startTestSpan(t)
+	meter, reader := newTestExemplarMeter(t, exemplar.TraceBasedFilter)
+
+	_ = meter.AddCounter(ctx, "requests", "Requests", "1", 1)
+	_ = meter.RecordHistogram(ctx, "duration", "Duration", "s", nil, 0.2)
+
+	got := collect(t, reader)
+	counterExemplars := got["requests"].(metricdata.Sum[float64]).DataPoints[0].Exemplars
+	histogramExemplars := got["duration"].(metricdata.Histogram[float64]).DataPoints[0].Exemplars
+	for name, exemplars := range map[string][]metricdata.Exemplar[float64]{
+		"requests": counterExemplars,
+		"duration": histogramExemplars,
+	} {
+		if len(exemplars) != 1 {
+			t.Errorf("expected an exemplar for %s, got %d", name, len(exemplars))
+			continue
+		}
+		if hex.EncodeToString(exemplars[0].TraceID) != ids.TraceID || hex.EncodeToString(exemplars[0].SpanID) != ids.SpanID {
+			t.Errorf("expected the exemplar of %s to carry %+v, got %+v", name, ids, exemplars[0])
+		}
+	}
+}
+
+func TestOtelMeter_ExemplarsRequireSampledSpan(t *testing.T) {
+	meter, reader := newTestExemplarMeter(t, exemplar.TraceBasedFilter)
+
+	_ = meter.AddCounter(context.Background(), "requests", "Requests", "1", 1)
+
+	if exemplars := collect(t, reader)["requests"].(metricdata.Sum[float64]).DataPoints[0].Exemplars; len(exemplars) != 0 {
+		t.Errorf("expected no exemplar without a span, got %+v", exemplars)
+	}
+}
+
+func TestPrometheusReader_ExposesExemplars(t *testing.T) {
+	ctx, ids := startTestSpan(t)
+	reader, handler, err := newPrometheusReader(Config{}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	_ = NewOtelMeter(provider.Meter("test")).AddCounter(ctx, "requests", "Requests", "1", 1)
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.Header.Set("Accept", "application/openmetrics-text; version=1.0.0")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	body, _ := io.ReadAll(rec.Body)
+
+	if !strings.Contains(string(body), `trace_id="`+ids.TraceID+`"`) {
+		t.Errorf("expected the exemplar trace id in the OpenMetrics output, got %s", body)
+	}
+}
+
+func TestExemplarFilter(t *testing.T) {
+	for _, f := range []ExemplarFilter{"", ExemplarFilterTraceBased, ExemplarFilterAlwaysOn, ExemplarFilterAlwaysOff} {
+		if _, err := exemplarFilter(f); err != nil {
+			t.Errorf("unexpected error for %q: %v", f, err)
+		}
+	}
+	if _, err := exemplarFilter("sometimes"); err == nil {
+		t.Error("expected an error for an unknown filter")
+	}
+}
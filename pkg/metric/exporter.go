@@ -0,0 +1,258 @@
+package metric
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/bruno303/go-toolkit/pkg/telemetry"
+	promclient "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"google.golang.org/grpc/credentials"
+)
+
+type (
+	ExporterType string
+	Temporality  string
+)
+
+const (
+	ExporterPrometheus ExporterType = "prometheus"
+	ExporterOTLPGRPC   ExporterType = "otlp-grpc"
+	ExporterOTLPHTTP   ExporterType = "otlp-http"
+	ExporterStdout     ExporterType = "stdout"
+	// ExporterPushgateway pushes the Prometheus metrics to a Pushgateway, for
+	// jobs finishing before they can be scraped.
+	ExporterPushgateway ExporterType = "pushgateway"
+	// ExporterNone exports nothing, disabling the export when used alone.
+	ExporterNone ExporterType = "none"
+
+	// TemporalityCumulative reports the totals since the start of the
+	// process, the default.
+	TemporalityCumulative Temporality = "cumulative"
+	// TemporalityDelta reports the change since the last export.
+	TemporalityDelta Temporality = "delta"
+	// TemporalityLowMemory uses delta for synchronous counters and histograms
+	// and cumulative for the other instruments.
+	TemporalityLowMemory Temporality = "lowmemory"
+)
+
+var errPrometheusNotConfigured = errors.New("the prometheus exporter is not configured")
+
+// newReaders returns a reader per configured exporter and the handler serving
+// the Prometheus metrics, nil when Prometheus is not configured. The producers
+// are attached to every reader. On error, the readers already created are
+// shut down.
+func newReaders(ctx context.Context, cfg Config, producers ...sdkmetric.Producer) ([]sdkmetric.Reader, http.Handler, error) {
+	selector, err := temporalitySelector(cfg.Temporality)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	exporters := cfg.Exporters
+	if len(exporters) == 0 {
+		exporters = []ExporterType{ExporterPrometheus}
+	}
+
+	var (
+		readers []sdkmetric.Reader
+		handler http.Handler
+	)
+	for _, exporterType := range exporters {
+		var exporter sdkmetric.Exporter
+		switch exporterType {
+		case ExporterPrometheus:
+			reader, promHandler, err := newPrometheusReader(cfg, producers)
+			if err != nil {
+				return nil, nil, errors.Join(err, shutdownReaders(ctx, readers))
+			}
+			readers = append(readers, reader)
+			handler = promHandler
+			continue
+		case ExporterPushgateway:
+			reader, err := newPushgatewayReader(cfg, producers)
+			if err != nil {
+				return nil, nil, errors.Join(err, shutdownReaders(ctx, readers))
+			}
+			readers = append(readers, reader)
+			continue
+		case ExporterOTLPGRPC:
+			exporter, err = newGRPCExporter(ctx, cfg.OTLP, selector)
+		case ExporterOTLPHTTP:
+			exporter, err = newHTTPExporter(ctx, cfg.OTLP, selector)
+		case ExporterStdout:
+			exporter, err = newStdoutExporter(cfg.Writer, cfg.PrettyPrint, selector)
+		case ExporterNone:
+			continue
+		default:
+			err = fmt.Errorf("unknown metric exporter type %q", exporterType)
+		}
+		if err != nil {
+			return nil, nil, errors.Join(err, shutdownReaders(ctx, readers))
+		}
+		readers = append(readers, newPeriodicReader(exporter, cfg.Interval, producers))
+	}
+	return readers, handler, nil
+}
+
+func shutdownReaders(ctx context.Context, readers []sdkmetric.Reader) error {
+	var errs []error
+	for _, reader := range readers {
+		errs = append(errs, reader.Shutdown(ctx))
+	}
+	return errors.Join(errs...)
+}
+
+func newPeriodicReader(exporter sdkmetric.Exporter, interval time.Duration, producers []sdkmetric.Producer) sdkmetric.Reader {
+	var opts []sdkmetric.PeriodicReaderOption
+	for _, producer := range producers {
+		opts = append(opts, sdkmetric.WithProducer(producer))
+	}
+	if interval > 0 {
+		opts = append(opts, sdkmetric.WithInterval(interval))
+	}
+	return sdkmetric.NewPeriodicReader(exporter, opts...)
+}
+
+func newPrometheusReader(cfg Config, producers []sdkmetric.Producer) (sdkmetric.Reader, http.Handler, error) {
+	reader, registry, err := newPrometheusRegistry(cfg, producers)
+	if err != nil {
+		return nil, nil, err
+	}
+	// OpenMetrics is required to expose the exemplars.
+	return reader, promhttp.HandlerFor(registry, promhttp.HandlerOpts{EnableOpenMetrics: true}), nil
+}
+
+// newPrometheusRegistry returns a Prometheus reader and the registry
+// gathering its metrics. The registry also holds the Prometheus Go and process
+// collectors, unless RuntimeMetrics and ProcessMetrics replace them with
+// their OTel equivalents.
+func newPrometheusRegistry(cfg Config, producers []sdkmetric.Producer) (sdkmetric.Reader, *promclient.Registry, error) {
+	// Each setup has its own registry, so it can be called more than once.
+	registry := promclient.NewRegistry()
+	if !cfg.RuntimeMetrics {
+		registry.MustRegister(collectors.NewGoCollector())
+	}
+	if !cfg.ProcessMetrics {
+		registry.MustRegister(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	}
+	opts := []prometheus.Option{prometheus.WithRegisterer(registry)}
+	for _, producer := range producers {
+		opts = append(opts, prometheus.WithProducer(producer))
+	}
+	exporter, err := prometheus.New(opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return exporter, registry, nil
+}
+
+func newGRPCExporter(ctx context.Context, otlp telemetry.OTLPConfig, selector sdkmetric.TemporalitySelector) (sdkmetric.Exporter, error) {
+	opts := []otlpmetricgrpc.Option{
+		otlpmetricgrpc.WithEndpoint(otlp.Endpoint),
+		otlpmetricgrpc.WithTemporalitySelector(selector),
+	}
+
+	if otlp.TLS.Enabled {
+		tlsCfg, err := otlp.TLS.Build()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, otlpmetricgrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg)))
+	} else {
+		opts = append(opts, otlpmetricgrpc.WithInsecure())
+	}
+	if len(otlp.Headers) > 0 {
+		opts = append(opts, otlpmetricgrpc.WithHeaders(otlp.Headers))
+	}
+	gzip, err := telemetry.UseGzip(otlp.Compression)
+	if err != nil {
+		return nil, err
+	}
+	if gzip {
+		opts = append(opts, otlpmetricgrpc.WithCompressor(telemetry.CompressionGzip))
+	}
+	if otlp.Timeout > 0 {
+		opts = append(opts, otlpmetricgrpc.WithTimeout(otlp.Timeout))
+	}
+
+	return otlpmetricgrpc.New(ctx, opts...)
+}
+
+func newHTTPExporter(ctx context.Context, otlp telemetry.OTLPConfig, selector sdkmetric.TemporalitySelector) (sdkmetric.Exporter, error) {
+	opts := []otlpmetrichttp.Option{
+		otlpmetrichttp.WithEndpoint(otlp.Endpoint),
+		otlpmetrichttp.WithTemporalitySelector(selector),
+	}
+
+	if otlp.TLS.Enabled {
+		tlsCfg, err := otlp.TLS.Build()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, otlpmetrichttp.WithTLSClientConfig(tlsCfg))
+	} else {
+		opts = append(opts, otlpmetrichttp.WithInsecure())
+	}
+	if len(otlp.Headers) > 0 {
+		opts = append(opts, otlpmetrichttp.WithHeaders(otlp.Headers))
+	}
+	gzip, err := telemetry.UseGzip(otlp.Compression)
+	if err != nil {
+		return nil, err
+	}
+	if gzip {
+		opts = append(opts, otlpmetrichttp.WithCompression(otlpmetrichttp.GzipCompression))
+	}
+	if otlp.Timeout > 0 {
+		opts = append(opts, otlpmetrichttp.WithTimeout(otlp.Timeout))
+	}
+	if otlp.URLPath != "" {
+		opts = append(opts, otlpmetrichttp.WithURLPath(otlp.URLPath))
+	}
+
+	return otlpmetrichttp.New(ctx, opts...)
+}
+
+func newStdoutExporter(w io.Writer, prettyPrint bool, selector sdkmetric.TemporalitySelector) (sdkmetric.Exporter, error) {
+	opts := []stdoutmetric.Option{stdoutmetric.WithTemporalitySelector(selector)}
+	if w != nil {
+		opts = append(opts, stdoutmetric.WithWriter(w))
+	}
+	if prettyPrint {
+		opts = append(opts, stdoutmetric.WithPrettyPrint())
+	}
+	return stdoutmetric.New(opts...)
+}
+
+func temporalitySelector(t Temporality) (sdkmetric.TemporalitySelector, error) {
+	switch t {
+	case "", TemporalityCumulative:
+		return sdkmetric.DefaultTemporalitySelector, nil
+	case TemporalityDelta:
+		return func(sdkmetric.InstrumentKind) metricdata.Temporality {
+			return metricdata.DeltaTemporality
+		}, nil
+	case TemporalityLowMemory:
+		return func(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+			switch kind {
+			case sdkmetric.InstrumentKindCounter, sdkmetric.InstrumentKindHistogram:
+				return metricdata.DeltaTemporality
+			default:
+				return metricdata.CumulativeTemporality
+			}
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown metric temporality %q", t)
+	}
+}
@@ -0,0 +1,150 @@
+package metric
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bruno303/go-toolkit/pkg/telemetry"
+)
+
+func TestNewMeterProvider_StdoutExporter(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	cfg := Config{
+		ApplicationName: "test-app",
+		Exporters:       []ExporterType{ExporterStdout},
+		Temporality:     TemporalityDelta,
+		Writer:          &buf,
+		DisableEnv:      true,
+	}
+
+	provider, handler, err := newMeterProvider(ctx, &cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if handler != nil {
+		t.Error("expected no handler without the prometheus exporter")
+	}
+
+	_ = NewOtelMeter(provider.Meter("test")).AddCounter(ctx, "jobs.processed", "Jobs", "1", 3)
+	if err := provider.Shutdown(ctx); err != nil {
+		t.Fatalf("failed to shutdown: %v", err)
+	}
+	if !strings.Contains(buf.String(), "jobs.processed") {
+		t.Errorf("expected stdout output to contain the metric, got %s", buf.String())
+	}
+}
+
+func TestNewMeterProvider_OTLPHTTPExporter(t *testing.T) {
+	ctx := context.Background()
+	var (
+		mu      sync.Mutex
+		paths   []string
+		headers []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		headers = append(headers, r.Header.Get("Api-Key"))
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := Config{
+		ApplicationName: "test-app",
+		Exporters:       []ExporterType{ExporterOTLPHTTP, ExporterPrometheus},
+		OTLP: telemetry.OTLPConfig{
+			Endpoint: strings.TrimPrefix(server.URL, "http://"),
+			Headers:  map[string]string{"api-key": "secret"},
+		},
+		DisableEnv: true,
+	}
+
+	provider, handler, err := newMeterProvider(ctx, &cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if handler == nil {
+		t.Error("expected a handler with the prometheus exporter")
+	}
+
+	_ = NewOtelMeter(provider.Meter("test")).AddCounter(ctx, "jobs.processed", "Jobs", "1", 1)
+	if err := provider.Shutdown(ctx); err != nil {
+		t.Fatalf("failed to shutdown: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(paths) == 0 {
+		t.Fatal("expected the metrics to be pushed")
+	}
+	if paths[0] != "/v1/metrics" || headers[0] != "secret" {
+		t.Errorf("unexpected request to %s with api-key %q", paths[0], headers[0])
+	}
+}
+
+func TestNewMeterProvider_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{"unknown exporter", Config{Exporters: []ExporterType{"zipkin"}}},
+		{"unknown temporality", Config{Exporters: []ExporterType{ExporterStdout}, Temporality: "weekly"}},
+		{"missing CA file", Config{
+			Exporters: []ExporterType{ExporterOTLPGRPC},
+			OTLP:      telemetry.OTLPConfig{TLS: telemetry.TLSConfig{Enabled: true, CAFile: "testdata/missing.pem"}},
+		}},
+		{"unknown gRPC compression", Config{Exporters: []ExporterType{ExporterOTLPGRPC}, OTLP: telemetry.OTLPConfig{Compression: "zstd"}}},
+		{"unknown HTTP compression", Config{Exporters: []ExporterType{ExporterOTLPHTTP}, OTLP: telemetry.OTLPConfig{Compression: "zstd"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.DisableEnv = true
+			if _, _, err := newMeterProvider(context.Background(), &tt.cfg); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestSetupOTelMetricsHandler_RequiresPrometheus(t *testing.T) {
+	_, _, err := SetupOTelMetricsHandler(context.Background(), Config{
+		Enabled:    true,
+		Exporters:  []ExporterType{ExporterNone},
+		DisableEnv: true,
+	})
+	if !errors.Is(err, errPrometheusNotConfigured) {
+		t.Errorf("expected errPrometheusNotConfigured, got %v", err)
+	}
+}
+
+func TestNewMeterProvider_RuntimeMetricsReplacePrometheusCollectors(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{ApplicationName: "test-app", RuntimeMetrics: true, ProcessMetrics: true, DisableEnv: true}
+
+	provider, handler, err := newMeterProvider(ctx, &cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() { _ = provider.Shutdown(ctx) })
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := rec.Body.String()
+	if !strings.Contains(body, "go_goroutine_count") || !strings.Contains(body, "process_uptime") {
+		t.Errorf("expected the OTel runtime and process metrics, got %s", body)
+	}
+	for _, name := range []string{"go_goroutines ", "process_start_time_seconds"} {
+		if strings.Contains(body, name) {
+			t.Errorf("expected the Prometheus collector metric %s to be left out", name)
+		}
+	}
+}
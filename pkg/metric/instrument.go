@@ -0,0 +1,171 @@
+package metric
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// InstrumentOpts describes an instrument created through the Meter handle
+// constructors.
+type InstrumentOpts struct {
+	Description string
+	Unit        string
+	// Buckets are the explicit bucket boundaries of histograms. The default
+	// boundaries are used when empty.
+	Buckets []float64
+}
+
+// Instrument handles are created once, e.g. when a component is built, and
+// reused on every recording. Creating a handle with a name already in use
+// returns the existing instrument. AddSet and RecordSet take a precomputed
+// AttributeSet, skipping the conversion of the attributes.
+type (
+	// Counter and Int64Counter are monotonic counters. Negative values are
+	// dropped and reported to the OTel error handler with
+	// ErrNegativeCounterValue.
+	Counter interface {
+		Add(ctx context.Context, value float64, attrs ...Attribute)
+		AddSet(ctx context.Context, value float64, set AttributeSet)
+	}
+	Int64Counter interface {
+		Add(ctx context.Context, value int64, attrs ...Attribute)
+		AddSet(ctx context.Context, value int64, set AttributeSet)
+	}
+	UpDownCounter interface {
+		Add(ctx context.Context, value float64, attrs ...Attribute)
+		AddSet(ctx context.Context, value float64, set AttributeSet)
+	}
+	Int64UpDownCounter interface {
+		Add(ctx context.Context, value int64, attrs ...Attribute)
+		AddSet(ctx context.Context, value int64, set AttributeSet)
+	}
+	Gauge interface {
+		Record(ctx context.Context, value float64, attrs ...Attribute)
+		RecordSet(ctx context.Context, value float64, set AttributeSet)
+	}
+	Int64Gauge interface {
+		Record(ctx context.Context, value int64, attrs ...Attribute)
+		RecordSet(ctx context.Context, value int64, set AttributeSet)
+	}
+	Histogram interface {
+		Record(ctx context.Context, value float64, attrs ...Attribute)
+		RecordSet(ctx context.Context, value float64, set AttributeSet)
+	}
+	Int64Histogram interface {
+		Record(ctx context.Context, value int64, attrs ...Attribute)
+		RecordSet(ctx context.Context, value int64, set AttributeSet)
+	}
+)
+
+type (
+	InstrumentKind string
+
+	// InstrumentInfo describes a registered instrument.
+	InstrumentInfo struct {
+		Name string
+		// Scope is the instrumentation scope of the meter, set for the meters
+		// of an OtelMeterProvider.
+		Scope       string
+		Kind        InstrumentKind
+		Description string
+		Unit        string
+		Buckets     []float64
+	}
+
+	// InstrumentRegistry is implemented by meters that keep track of the
+	// instruments they declare.
+	InstrumentRegistry interface {
+		Instruments() []InstrumentInfo
+	}
+
+	// ConflictError is returned when an instrument name is declared again
+	// with a different kind, description, unit or buckets, in the same meter
+	// or in another scope of the same OtelMeterProvider, as exporters such as
+	// Prometheus merge the scopes.
+	ConflictError struct {
+		Registered InstrumentInfo
+		Requested  InstrumentInfo
+	}
+)
+
+const (
+	InstrumentCounter                 InstrumentKind = "counter"
+	InstrumentInt64Counter            InstrumentKind = "int64_counter"
+	InstrumentUpDownCounter           InstrumentKind = "up_down_counter"
+	InstrumentInt64UpDownCounter      InstrumentKind = "int64_up_down_counter"
+	InstrumentGauge                   InstrumentKind = "gauge"
+	InstrumentInt64Gauge              InstrumentKind = "int64_gauge"
+	InstrumentHistogram               InstrumentKind = "histogram"
+	InstrumentInt64Histogram          InstrumentKind = "int64_histogram"
+	InstrumentObservableGauge         InstrumentKind = "observable_gauge"
+	InstrumentObservableCounter       InstrumentKind = "observable_counter"
+	InstrumentObservableUpDownCounter InstrumentKind = "observable_up_down_counter"
+)
+
+var ErrInstrumentConflict = errors.New("conflicting instrument declaration")
+
+// Instruments returns the instruments declared through the meters of the
+// global meter provider, whatever their scope, and through the global meter,
+// sorted by name. It returns nil when none of them keeps a registry.
+func Instruments() []InstrumentInfo {
+	var infos []InstrumentInfo
+	provider, _ := GetMeterProvider().(*OtelMeterProvider)
+	if provider != nil {
+		infos = provider.Instruments()
+	}
+	meter := GetMeter()
+	if registry, ok := meter.(InstrumentRegistry); ok && (provider == nil || !provider.holds(meter)) {
+		infos = append(infos, registry.Instruments()...)
+	}
+	sortInstruments(infos)
+	return infos
+}
+
+// sortInstruments sorts infos by name, then scope.
+func sortInstruments(infos []InstrumentInfo) {
+	slices.SortFunc(infos, func(a, b InstrumentInfo) int {
+		return cmp.Or(strings.Compare(a.Name, b.Name), strings.Compare(a.Scope, b.Scope))
+	})
+}
+
+func newInstrumentInfo(kind InstrumentKind, name string, opts InstrumentOpts) InstrumentInfo {
+	return InstrumentInfo{
+		Name:        name,
+		Kind:        kind,
+		Description: opts.Description,
+		Unit:        opts.Unit,
+		Buckets:     opts.Buckets,
+	}
+}
+
+func (i InstrumentInfo) String() string {
+	s := fmt.Sprintf("%s %s unit=%q description=%q", i.Name, i.Kind, i.Unit, i.Description)
+	if len(i.Buckets) > 0 {
+		s += fmt.Sprintf(" buckets=%v", i.Buckets)
+	}
+	if i.Scope != "" {
+		s += fmt.Sprintf(" scope=%s", i.Scope)
+	}
+	return s
+}
+
+// conflicts reports whether other declares the same name differently. Empty
+// buckets in other match any buckets.
+func (i InstrumentInfo) conflicts(other InstrumentInfo) bool {
+	return i.Kind != other.Kind ||
+		i.Description != other.Description ||
+		i.Unit != other.Unit ||
+		(len(other.Buckets) > 0 && !slices.Equal(i.Buckets, other.Buckets))
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s: registered as [%s], requested as [%s]", ErrInstrumentConflict, e.Registered, e.Requested)
+}
+
+func (e *ConflictError) Unwrap() error {
+	return ErrInstrumentConflict
+}
@@ -0,0 +1,169 @@
+package metric
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+func TestOtelMeter_ConflictingDeclarations(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		second func(m *OtelMeter) error
+	}{
+		{"kind", func(m *OtelMeter) error {
+			return m.AddUpDownCounter(ctx, "requests", "Requests", "1", 1)
+		}},
+		{"unit", func(m *OtelMeter) error {
+			return m.AddCounter(ctx, "requests", "Requests", "ms", 1)
+		}},
+		{"description", func(m *OtelMeter) error {
+			return m.AddCounter(ctx, "requests", "Other", "1", 1)
+		}},
+		{"buckets", func(m *OtelMeter) error {
+			_, err := m.Histogram("latency", InstrumentOpts{Unit: "s", Buckets: []float64{1, 2}})
+			return err
+		}},
+		{"observable", func(m *OtelMeter) error {
+			_, err := m.ObserveGauge("requests", "Requests", "1", func(context.Context, Observer) error { return nil })
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meter, _ := newTestOtelMeter(t)
+			if err := meter.AddCounter(ctx, "requests", "Requests", "1", 1); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, err := meter.Histogram("latency", InstrumentOpts{Unit: "s", Buckets: []float64{1}}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			err := tt.second(meter)
+			var conflict *ConflictError
+			if !errors.As(err, &conflict) {
+				t.Fatalf("expected a ConflictError, got %v", err)
+			}
+			if !errors.Is(err, ErrInstrumentConflict) {
+				t.Error("expected error to match ErrInstrumentConflict")
+			}
+		})
+	}
+}
+
+func TestOtelMeter_SameDeclarationDoesNotConflict(t *testing.T) {
+	meter, _ := newTestOtelMeter(t)
+
+	if _, err := meter.Histogram("latency", InstrumentOpts{Unit: "s", Buckets: []float64{1}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := meter.Histogram("latency", InstrumentOpts{Unit: "s"}); err != nil {
+		t.Errorf("expected histogram without buckets to reuse the registered one, got %v", err)
+	}
+	if err := meter.RecordHistogram(context.Background(), "latency", "", "s", []float64{1}, 0.5); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestOtelMeter_StrictPanicsOnConflict(t *testing.T) {
+	meter, _ := newTestOtelMeter(t)
+	strict := NewOtelMeterWithOpts(meter.meter, OtelMeterOpts{Strict: true})
+	_ = strict.AddCounter(context.Background(), "requests", "Requests", "1", 1)
+
+	defer func() {
+		r := recover()
+		if err, ok := r.(error); !ok || !errors.Is(err, ErrInstrumentConflict) {
+			t.Errorf("expected panic with ErrInstrumentConflict, got %v", r)
+		}
+	}()
+	_ = strict.AddGauge(context.Background(), "requests", "Requests", "1", 1)
+}
+
+func TestInstruments(t *testing.T) {
+	meter, _ := newTestOtelMeter(t)
+	restore := ReplaceMeter(meter)
+	defer restore()
+
+	_ = meter.AddGauge(context.Background(), "temperature", "Temperature", "Cel", 20)
+	_, _ = meter.Histogram("latency", InstrumentOpts{Description: "Latency", Unit: "s", Buckets: []float64{0.1, 1}})
+
+	infos := Instruments()
+	if len(infos) != 2 {
+		t.Fatalf("expected 2 instruments, got %d", len(infos))
+	}
+	expected := []string{
+		`latency histogram unit="s" description="Latency" buckets=[0.1 1]`,
+		`temperature gauge unit="Cel" description="Temperature"`,
+	}
+	for i, info := range infos {
+		if info.String() != expected[i] {
+			t.Errorf("expected %s, got %s", expected[i], info.String())
+		}
+	}
+
+	restoreNoOp := ReplaceMeter(NewNoOpMeter())
+	defer restoreNoOp()
+	restoreProvider := ReplaceMeterProvider(NewNoOpMeterProvider())
+	defer restoreProvider()
+	if Instruments() != nil {
+		t.Error("expected no instruments for the no-op meter")
+	}
+}
+
+func TestInstruments_AllScopes(t *testing.T) {
+	ctx := context.Background()
+	provider := NewOtelMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewManualReader())))
+	t.Cleanup(func() { _ = provider.Shutdown(ctx) })
+	restoreProvider := ReplaceMeterProvider(provider)
+	defer restoreProvider()
+	restoreMeter := ReplaceMeter(provider.Meter("app"))
+	defer restoreMeter()
+
+	_ = GetMeter().AddCounter(ctx, "app.requests", "Requests", "1", 1)
+	_, _ = NewMeter("orders").Int64Counter("orders.created", InstrumentOpts{Description: "Orders", Unit: "1"})
+	if _, err := NewRED("checkout"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var names []string
+	for _, info := range Instruments() {
+		names = append(names, info.Name+" "+info.Scope)
+	}
+	expected := []string{
+		"app.requests app",
+		"checkout.duration " + instrumentationName,
+		"checkout.errors " + instrumentationName,
+		"checkout.requests " + instrumentationName,
+		"orders.created orders",
+	}
+	if !slices.Equal(names, expected) {
+		t.Errorf("expected instruments %v, got %v", expected, names)
+	}
+}
+
+func TestOtelMeterProvider_ConflictAcrossScopes(t *testing.T) {
+	provider := NewOtelMeterProvider(sdkmetric.NewMeterProvider())
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	if _, err := provider.Meter("orders").Counter("requests", InstrumentOpts{Unit: "1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := provider.Meter("payments").Counter("requests", InstrumentOpts{Unit: "1"}); err != nil {
+		t.Errorf("expected the same declaration to be accepted in another scope, got %v", err)
+	}
+
+	_, err := provider.Meter("shipping").Histogram("requests", InstrumentOpts{Unit: "s"})
+	var conflict *ConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("expected a conflict error, got %v", err)
+	}
+	if conflict.Registered.Scope != "orders" || conflict.Requested.Scope != "shipping" {
+		t.Errorf("unexpected conflict scopes: %s", conflict)
+	}
+}
@@ -2,7 +2,9 @@ package metric
 
 import (
 	"context"
+	"errors"
 	"sync"
+	"sync/atomic"
 )
 
 type Attribute struct {
@@ -10,31 +12,93 @@ type Attribute struct {
 	Value any
 }
 
+// Meter records measurements. Instruments are identified by name and created
+// on first use.
 type Meter interface {
-	AddGauge(ctx context.Context, name string, description string, unit string, value float64, attrs ...Attribute) error
+	// AddCounter increments a monotonic counter. Negative values are rejected
+	// with ErrNegativeCounterValue; use AddUpDownCounter for values that decrease.
 	AddCounter(ctx context.Context, name string, description string, unit string, value float64, attrs ...Attribute) error
+	AddInt64Counter(ctx context.Context, name string, description string, unit string, value int64, attrs ...Attribute) error
+	AddUpDownCounter(ctx context.Context, name string, description string, unit string, value float64, attrs ...Attribute) error
+	AddInt64UpDownCounter(ctx context.Context, name string, description string, unit string, value int64, attrs ...Attribute) error
+	AddGauge(ctx context.Context, name string, description string, unit string, value float64, attrs ...Attribute) error
+	AddInt64Gauge(ctx context.Context, name string, description string, unit string, value int64, attrs ...Attribute) error
+	// RecordHistogram records a value in a histogram. When buckets is empty
+	// the default bucket boundaries are used.
+	RecordHistogram(ctx context.Context, name string, description string, unit string, buckets []float64, value float64, attrs ...Attribute) error
+	RecordInt64Histogram(ctx context.Context, name string, description string, unit string, buckets []float64, value int64, attrs ...Attribute) error
+	// ObserveGauge, ObserveCounter and ObserveUpDownCounter register
+	// asynchronous instruments whose values are reported by fn on every
+	// collection. The returned function unregisters fn.
+	ObserveGauge(name string, description string, unit string, fn ObserveFunc) (unregister func() error, err error)
+	ObserveCounter(name string, description string, unit string, fn ObserveFunc) (unregister func() error, err error)
+	ObserveUpDownCounter(name string, description string, unit string, fn ObserveFunc) (unregister func() error, err error)
+
+	// Counter, Int64Counter, UpDownCounter, Int64UpDownCounter, Gauge,
+	// Int64Gauge, Histogram and Int64Histogram return reusable instrument
+	// handles, avoiding the name lookup of the methods above on hot paths.
+	Counter(name string, opts InstrumentOpts) (Counter, error)
+	Int64Counter(name string, opts InstrumentOpts) (Int64Counter, error)
+	UpDownCounter(name string, opts InstrumentOpts) (UpDownCounter, error)
+	Int64UpDownCounter(name string, opts InstrumentOpts) (Int64UpDownCounter, error)
+	Gauge(name string, opts InstrumentOpts) (Gauge, error)
+	Int64Gauge(name string, opts InstrumentOpts) (Int64Gauge, error)
+	Histogram(name string, opts InstrumentOpts) (Histogram, error)
+	Int64Histogram(name string, opts InstrumentOpts) (Int64Histogram, error)
 }
 
+// Observer receives the values reported by an ObserveFunc.
+type Observer interface {
+	Observe(value float64, attrs ...Attribute)
+}
+
+// ObserveFunc reports the current values of an asynchronous instrument.
+type ObserveFunc func(ctx context.Context, o Observer) error
+
 type MeterProvider interface {
 	Meter(name string) Meter
 	Shutdown(ctx context.Context) error
 }
 
+type meterHolder struct {
+	meter Meter
+}
+
 var (
-	globalMeter Meter = NewNoOpMeter()
+	ErrNegativeCounterValue = errors.New("counter value must not be negative")
+
+	globalMeter atomic.Pointer[meterHolder]
 	once        sync.Once
 )
 
+func init() {
+	globalMeter.Store(&meterHolder{meter: NewNoOpMeter()})
+}
+
 func GetMeter() Meter {
-	return globalMeter
+	return globalMeter.Load().meter
 }
 
+// SetMeter sets the global meter. Only the first call has effect; use
+// ReplaceMeter to override it afterwards.
 func SetMeter(m Meter) {
 	once.Do(func() {
-		globalMeter = m
+		globalMeter.Store(&meterHolder{meter: m})
 	})
 }
 
+// ReplaceMeter replaces the global meter, regardless of previous SetMeter
+// calls, and returns a function that restores the previous one.
+func ReplaceMeter(m Meter) (restore func()) {
+	previous := globalMeter.Swap(&meterHolder{meter: m})
+	return func() {
+		globalMeter.Store(previous)
+	}
+}
+
+// NewAttribute accepts any value. Prefer the typed constructors, such as
+// String and Int, as values of unsupported types are recorded with their fmt
+// representation and reported to the OTel error handler.
 func NewAttribute(key string, value any) Attribute {
 	return Attribute{Key: key, Value: value}
 }
@@ -24,6 +24,38 @@ func TestNoOpMeter(t *testing.T) {
 	}
 }
 
+func TestNoOpMeter_AllInstruments(t *testing.T) {
+	ctx := context.Background()
+	meter := NewNoOpMeter()
+
+	errs := []error{
+		meter.AddInt64Counter(ctx, "counter", "test", "1", 1),
+		meter.AddUpDownCounter(ctx, "updown", "test", "1", -1),
+		meter.AddInt64UpDownCounter(ctx, "updown.int", "test", "1", -1),
+		meter.AddInt64Gauge(ctx, "gauge.int", "test", "1", 1),
+		meter.RecordHistogram(ctx, "histogram", "test", "ms", []float64{10, 100}, 50),
+		meter.RecordInt64Histogram(ctx, "histogram.int", "test", "ms", nil, 50),
+	}
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	observe := func(ctx context.Context, o Observer) error { return nil }
+	for _, register := range []func(string, string, string, ObserveFunc) (func() error, error){
+		meter.ObserveGauge, meter.ObserveCounter, meter.ObserveUpDownCounter,
+	} {
+		unregister, err := register("observable", "test", "1", observe)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := unregister(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
 func TestGlobalMeter(t *testing.T) {
 	ctx := context.Background()
 
@@ -54,6 +86,21 @@ func TestSetMeter(t *testing.T) {
 	}
 }
 
+func TestReplaceMeter(t *testing.T) {
+	original := GetMeter()
+
+	replacement := NewNoOpMeter()
+	restore := ReplaceMeter(replacement)
+	if GetMeter() != replacement {
+		t.Error("expected replaced meter")
+	}
+
+	restore()
+	if GetMeter() != original {
+		t.Error("expected original meter to be restored")
+	}
+}
+
 func TestNewAttribute(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -0,0 +1,85 @@
+package metrictest
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bruno303/go-toolkit/pkg/metric"
+)
+
+// AssertRecorded checks that a metric with the given name was recorded.
+func AssertRecorded(t testing.TB, r *Recorder, name string) {
+	t.Helper()
+	if _, ok := r.Metric(name); !ok {
+		t.Errorf("expected metric %s to be recorded, got %v", name, r.Names())
+	}
+}
+
+// AssertNotRecorded checks that no metric with the given name was recorded.
+func AssertNotRecorded(t testing.TB, r *Recorder, name string) {
+	t.Helper()
+	if _, ok := r.Metric(name); ok {
+		t.Errorf("expected metric %s not to be recorded", name)
+	}
+}
+
+// AssertCounter checks the value of the counter or up-down counter name for
+// exactly the attributes attrs.
+func AssertCounter(t testing.TB, r *Recorder, name string, attrs []metric.Attribute, expected float64) {
+	t.Helper()
+	value, ok := r.Sum(name, attrs...)
+	if !ok {
+		t.Errorf("expected counter %s to be recorded with attributes %v", name, attrs)
+		return
+	}
+	if !equalFloat(value, expected) {
+		t.Errorf("expected counter %s with attributes %v to be %v, got %v", name, attrs, expected, value)
+	}
+}
+
+// AssertGauge checks the last value of the gauge name for exactly the
+// attributes attrs.
+func AssertGauge(t testing.TB, r *Recorder, name string, attrs []metric.Attribute, expected float64) {
+	t.Helper()
+	value, ok := r.Gauge(name, attrs...)
+	if !ok {
+		t.Errorf("expected gauge %s to be recorded with attributes %v", name, attrs)
+		return
+	}
+	if !equalFloat(value, expected) {
+		t.Errorf("expected gauge %s with attributes %v to be %v, got %v", name, attrs, expected, value)
+	}
+}
+
+// AssertHistogramCount checks the number of values recorded in the histogram
+// name for exactly the attributes attrs.
+func AssertHistogramCount(t testing.TB, r *Recorder, name string, attrs []metric.Attribute, expected uint64) {
+	t.Helper()
+	point, ok := r.Histogram(name, attrs...)
+	if !ok {
+		t.Errorf("expected histogram %s to be recorded with attributes %v", name, attrs)
+		return
+	}
+	if point.Count != expected {
+		t.Errorf("expected histogram %s with attributes %v to have %d values, got %d", name, attrs, expected, point.Count)
+	}
+}
+
+// AssertHistogramSum checks the sum of the values recorded in the histogram
+// name for exactly the attributes attrs.
+func AssertHistogramSum(t testing.TB, r *Recorder, name string, attrs []metric.Attribute, expected float64) {
+	t.Helper()
+	point, ok := r.Histogram(name, attrs...)
+	if !ok {
+		t.Errorf("expected histogram %s to be recorded with attributes %v", name, attrs)
+		return
+	}
+	if !equalFloat(point.Sum, expected) {
+		t.Errorf("expected histogram %s with attributes %v to sum %v, got %v", name, attrs, expected, point.Sum)
+	}
+}
+
+// equalFloat tolerates the rounding of summing floats.
+func equalFloat(a float64, b float64) bool {
+	return math.Abs(a-b) <= 1e-9*math.Max(1, math.Max(math.Abs(a), math.Abs(b)))
+}
@@ -0,0 +1,173 @@
+// Package metrictest provides an in-memory metric recorder and assertions to
+// verify the measurements recorded through the metric package.
+package metrictest
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/bruno303/go-toolkit/pkg/metric"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+type (
+	Recorder struct {
+		t        testing.TB
+		reader   *sdkmetric.ManualReader
+		provider *sdkmetric.MeterProvider
+		meter    *metric.OtelMeter
+	}
+
+	// HistogramPoint is the aggregation of a histogram for an attribute set.
+	HistogramPoint struct {
+		Count        uint64
+		Sum          float64
+		Bounds       []float64
+		BucketCounts []uint64
+	}
+)
+
+// NewRecorder installs a meter recording in memory as the global meter, and
+// its provider as the global meter provider and OTel meter provider, so the
+// meters of metric.NewMeter are recorded too. They are restored when the test
+// finishes, so tests using a Recorder must not run in parallel.
+func NewRecorder(t testing.TB) *Recorder {
+	t.Helper()
+
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	meter := metric.NewOtelMeter(provider.Meter("metrictest"))
+
+	previousProvider := otel.GetMeterProvider()
+	otel.SetMeterProvider(provider)
+	restoreMeter := metric.ReplaceMeter(meter)
+	restoreProvider := metric.ReplaceMeterProvider(metric.NewOtelMeterProvider(provider))
+
+	t.Cleanup(func() {
+		restoreProvider()
+		restoreMeter()
+		otel.SetMeterProvider(previousProvider)
+		_ = provider.Shutdown(context.Background())
+	})
+
+	return &Recorder{t: t, reader: reader, provider: provider, meter: meter}
+}
+
+// Meter returns the recording meter, to be injected in the code under test
+// instead of using the global meter.
+func (r *Recorder) Meter() *metric.OtelMeter {
+	return r.meter
+}
+
+// Metrics collects the recorded metrics, running the callbacks of the
+// observable instruments.
+func (r *Recorder) Metrics() []metricdata.Metrics {
+	var rm metricdata.ResourceMetrics
+	if err := r.reader.Collect(context.Background(), &rm); err != nil {
+		r.t.Errorf("failed to collect metrics: %v", err)
+		return nil
+	}
+	var metrics []metricdata.Metrics
+	for _, sm := range rm.ScopeMetrics {
+		metrics = append(metrics, sm.Metrics...)
+	}
+	return metrics
+}
+
+// Metric returns the recorded metric with the given name.
+func (r *Recorder) Metric(name string) (metricdata.Metrics, bool) {
+	for _, m := range r.Metrics() {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return metricdata.Metrics{}, false
+}
+
+// Names returns the names of the recorded metrics, sorted.
+func (r *Recorder) Names() []string {
+	var names []string
+	for _, m := range r.Metrics() {
+		names = append(names, m.Name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Sum returns the value of the counter or up-down counter name for exactly
+// the attributes attrs.
+func (r *Recorder) Sum(name string, attrs ...metric.Attribute) (float64, bool) {
+	m, ok := r.Metric(name)
+	if !ok {
+		return 0, false
+	}
+	set := metric.OtelAttributeSet(attrs...)
+	switch data := m.Data.(type) {
+	case metricdata.Sum[float64]:
+		return findValue(data.DataPoints, set)
+	case metricdata.Sum[int64]:
+		return findValue(data.DataPoints, set)
+	}
+	return 0, false
+}
+
+// Gauge returns the last value of the gauge name for exactly the attributes
+// attrs.
+func (r *Recorder) Gauge(name string, attrs ...metric.Attribute) (float64, bool) {
+	m, ok := r.Metric(name)
+	if !ok {
+		return 0, false
+	}
+	set := metric.OtelAttributeSet(attrs...)
+	switch data := m.Data.(type) {
+	case metricdata.Gauge[float64]:
+		return findValue(data.DataPoints, set)
+	case metricdata.Gauge[int64]:
+		return findValue(data.DataPoints, set)
+	}
+	return 0, false
+}
+
+// Histogram returns the aggregation of the histogram name for exactly the
+// attributes attrs.
+func (r *Recorder) Histogram(name string, attrs ...metric.Attribute) (HistogramPoint, bool) {
+	m, ok := r.Metric(name)
+	if !ok {
+		return HistogramPoint{}, false
+	}
+	set := metric.OtelAttributeSet(attrs...)
+	switch data := m.Data.(type) {
+	case metricdata.Histogram[float64]:
+		return findHistogram(data.DataPoints, set)
+	case metricdata.Histogram[int64]:
+		return findHistogram(data.DataPoints, set)
+	}
+	return HistogramPoint{}, false
+}
+
+func findValue[N int64 | float64](points []metricdata.DataPoint[N], set attribute.Set) (float64, bool) {
+	for _, dp := range points {
+		if dp.Attributes.Equals(&set) {
+			return float64(dp.Value), true
+		}
+	}
+	return 0, false
+}
+
+func findHistogram[N int64 | float64](points []metricdata.HistogramDataPoint[N], set attribute.Set) (HistogramPoint, bool) {
+	for _, dp := range points {
+		if dp.Attributes.Equals(&set) {
+			return HistogramPoint{
+				Count:        dp.Count,
+				Sum:          float64(dp.Sum),
+				Bounds:       dp.Bounds,
+				BucketCounts: dp.BucketCounts,
+			}, true
+		}
+	}
+	return HistogramPoint{}, false
+}
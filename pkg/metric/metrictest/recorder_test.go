@@ -0,0 +1,102 @@
+package metrictest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bruno303/go-toolkit/pkg/metric"
+)
+
+func TestRecorder(t *testing.T) {
+	rec := NewRecorder(t)
+	ctx := context.Background()
+	meter := metric.GetMeter()
+	get := []metric.Attribute{metric.NewAttribute("method", "GET")}
+
+	_ = meter.AddCounter(ctx, "requests", "Requests", "1", 2, get...)
+	_ = meter.AddInt64Counter(ctx, "requests.bytes", "Bytes", "By", 512)
+	_ = meter.AddUpDownCounter(ctx, "connections", "Connections", "1", -1)
+	_ = meter.AddInt64Gauge(ctx, "queue.size", "Queue size", "1", 7)
+	_ = meter.RecordHistogram(ctx, "duration", "Duration", "s", nil, 0.1, get...)
+	_ = meter.RecordHistogram(ctx, "duration", "Duration", "s", nil, 0.2, get...)
+	_, _ = meter.ObserveGauge("pool.idle", "Idle", "1", func(ctx context.Context, o metric.Observer) error {
+		o.Observe(3)
+		return nil
+	})
+
+	AssertRecorded(t, rec, "requests")
+	AssertNotRecorded(t, rec, "errors")
+	AssertCounter(t, rec, "requests", get, 2)
+	AssertCounter(t, rec, "requests.bytes", nil, 512)
+	AssertCounter(t, rec, "connections", nil, -1)
+	AssertGauge(t, rec, "queue.size", nil, 7)
+	AssertGauge(t, rec, "pool.idle", nil, 3)
+	AssertHistogramCount(t, rec, "duration", get, 2)
+	AssertHistogramSum(t, rec, "duration", get, 0.3)
+
+	if _, ok := rec.Sum("requests"); ok {
+		t.Error("expected attributes to match exactly")
+	}
+	if _, ok := rec.Sum("queue.size"); ok {
+		t.Error("expected Sum to ignore gauges")
+	}
+}
+
+func TestRecorder_Assertions(t *testing.T) {
+	rec := NewRecorder(t)
+	_ = rec.Meter().AddCounter(context.Background(), "requests", "Requests", "1", 1)
+
+	tests := []struct {
+		name   string
+		assert func(t testing.TB)
+	}{
+		{name: "missing metric", assert: func(t testing.TB) { AssertRecorded(t, rec, "errors") }},
+		{name: "unexpected metric", assert: func(t testing.TB) { AssertNotRecorded(t, rec, "requests") }},
+		{name: "wrong value", assert: func(t testing.TB) { AssertCounter(t, rec, "requests", nil, 2) }},
+		{name: "wrong attributes", assert: func(t testing.TB) {
+			AssertCounter(t, rec, "requests", []metric.Attribute{metric.NewAttribute("method", "GET")}, 1)
+		}},
+		{name: "not a histogram", assert: func(t testing.TB) { AssertHistogramCount(t, rec, "requests", nil, 1) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &failureRecorder{TB: t}
+			tt.assert(mock)
+			if !mock.failed {
+				t.Error("expected the assertion to fail")
+			}
+		})
+	}
+}
+
+func TestRecorder_RecordsScopedMeters(t *testing.T) {
+	rec := NewRecorder(t)
+	_ = metric.NewMeter("github.com/acme/orders").AddCounter(context.Background(), "orders.created", "Orders", "1", 1)
+
+	AssertCounter(t, rec, "orders.created", nil, 1)
+}
+
+func TestRecorder_RestoresMeter(t *testing.T) {
+	original := metric.GetMeter()
+
+	t.Run("recording", func(t *testing.T) {
+		rec := NewRecorder(t)
+		if metric.GetMeter() != rec.Meter() {
+			t.Errorf("expected the recording meter, got %T", metric.GetMeter())
+		}
+	})
+
+	if metric.GetMeter() != original {
+		t.Errorf("expected meter to be restored, got %T", metric.GetMeter())
+	}
+}
+
+// failureRecorder records failed assertions instead of failing the test.
+type failureRecorder struct {
+	testing.TB
+	failed bool
+}
+
+func (f *failureRecorder) Errorf(string, ...any) {
+	f.failed = true
+}
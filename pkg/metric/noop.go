@@ -4,7 +4,12 @@ import (
 	"context"
 )
 
-type NoOpMeter struct{}
+type (
+	NoOpMeter struct{}
+
+	noopFloat64Instrument struct{}
+	noopInt64Instrument   struct{}
+)
 
 var _ Meter = (*NoOpMeter)(nil)
 
@@ -16,6 +21,94 @@ func (m *NoOpMeter) AddCounter(ctx context.Context, name string, description str
 	return nil
 }
 
+func (m *NoOpMeter) AddInt64Counter(ctx context.Context, name string, description string, unit string, value int64, attrs ...Attribute) error {
+	return nil
+}
+
+func (m *NoOpMeter) AddUpDownCounter(ctx context.Context, name string, description string, unit string, value float64, attrs ...Attribute) error {
+	return nil
+}
+
+func (m *NoOpMeter) AddInt64UpDownCounter(ctx context.Context, name string, description string, unit string, value int64, attrs ...Attribute) error {
+	return nil
+}
+
 func (m *NoOpMeter) AddGauge(ctx context.Context, name string, description string, unit string, value float64, attrs ...Attribute) error {
 	return nil
 }
+
+func (m *NoOpMeter) AddInt64Gauge(ctx context.Context, name string, description string, unit string, value int64, attrs ...Attribute) error {
+	return nil
+}
+
+func (m *NoOpMeter) RecordHistogram(ctx context.Context, name string, description string, unit string, buckets []float64, value float64, attrs ...Attribute) error {
+	return nil
+}
+
+func (m *NoOpMeter) RecordInt64Histogram(ctx context.Context, name string, description string, unit string, buckets []float64, value int64, attrs ...Attribute) error {
+	return nil
+}
+
+func (m *NoOpMeter) ObserveGauge(name string, description string, unit string, fn ObserveFunc) (func() error, error) {
+	return noopUnregister, nil
+}
+
+func (m *NoOpMeter) ObserveCounter(name string, description string, unit string, fn ObserveFunc) (func() error, error) {
+	return noopUnregister, nil
+}
+
+func (m *NoOpMeter) ObserveUpDownCounter(name string, description string, unit string, fn ObserveFunc) (func() error, error) {
+	return noopUnregister, nil
+}
+
+func noopUnregister() error {
+	return nil
+}
+
+func (m *NoOpMeter) Counter(name string, opts InstrumentOpts) (Counter, error) {
+	return noopFloat64Instrument{}, nil
+}
+
+func (m *NoOpMeter) Int64Counter(name string, opts InstrumentOpts) (Int64Counter, error) {
+	return noopInt64Instrument{}, nil
+}
+
+func (m *NoOpMeter) UpDownCounter(name string, opts InstrumentOpts) (UpDownCounter, error) {
+	return noopFloat64Instrument{}, nil
+}
+
+func (m *NoOpMeter) Int64UpDownCounter(name string, opts InstrumentOpts) (Int64UpDownCounter, error) {
+	return noopInt64Instrument{}, nil
+}
+
+func (m *NoOpMeter) Gauge(name string, opts InstrumentOpts) (Gauge, error) {
+	return noopFloat64Instrument{}, nil
+}
+
+func (m *NoOpMeter) Int64Gauge(name string, opts InstrumentOpts) (Int64Gauge, error) {
+	return noopInt64Instrument{}, nil
+}
+
+func (m *NoOpMeter) Histogram(name string, opts InstrumentOpts) (Histogram, error) {
+	return noopFloat64Instrument{}, nil
+}
+
+func (m *NoOpMeter) Int64Histogram(name string, opts InstrumentOpts) (Int64Histogram, error) {
+	return noopInt64Instrument{}, nil
+}
+
+func (noopFloat64Instrument) Add(ctx context.Context, value float64, attrs ...Attribute) {}
+
+func (noopFloat64Instrument) Record(ctx context.Context, value float64, attrs ...Attribute) {}
+
+func (noopFloat64Instrument) AddSet(ctx context.Context, value float64, set AttributeSet) {}
+
+func (noopFloat64Instrument) RecordSet(ctx context.Context, value float64, set AttributeSet) {}
+
+func (noopInt64Instrument) Add(ctx context.Context, value int64, attrs ...Attribute) {}
+
+func (noopInt64Instrument) Record(ctx context.Context, value int64, attrs ...Attribute) {}
+
+func (noopInt64Instrument) AddSet(ctx context.Context, value int64, set AttributeSet) {}
+
+func (noopInt64Instrument) RecordSet(ctx context.Context, value int64, set AttributeSet) {}
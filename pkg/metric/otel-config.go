@@ -2,72 +2,246 @@ package metric
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"time"
 
 	"github.com/bruno303/go-toolkit/pkg/log"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
-	"go.opentelemetry.io/otel/exporters/prometheus"
+	"github.com/bruno303/go-toolkit/pkg/telemetry"
+	"go.opentelemetry.io/otel"
+	otelmetric "go.opentelemetry.io/otel/metric"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
-	"go.opentelemetry.io/otel/sdk/resource"
-	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
 )
 
+const defaultMetricsPath = "/metrics"
+
 type Config struct {
 	ApplicationName    string
 	ApplicationVersion string
 	Environment        string
 	Enabled            bool
-	Port               int
-	Path               string
-	Log                log.Logger
+	// Exporters selects where the metrics go. Defaults to ExporterPrometheus.
+	Exporters []ExporterType
+	// Port and Path are where the Prometheus metrics are served, /metrics by default.
+	Port int
+	Path string
+	// OTLP configures the OTLP exporters; use trace.Config.OTLP to share the
+	// trace settings.
+	OTLP telemetry.OTLPConfig
+	// Interval is how often the push exporters (OTLP, stdout and
+	// Pushgateway) export. Zero uses the OTel default of 60s.
+	Interval time.Duration
+	// Temporality of the push exporters. Defaults to TemporalityCumulative.
+	Temporality Temporality
+	// Writer and PrettyPrint configure the stdout exporter, which writes to
+	// os.Stdout by default.
+	Writer      io.Writer
+	PrettyPrint bool
+	// Pushgateway configures ExporterPushgateway.
+	Pushgateway PushgatewayConfig
+	// Views rename instruments, filter their attributes, change their
+	// histogram buckets or cardinality limit.
+	Views []View
+	// CardinalityLimit caps the number of attribute sets of each instrument
+	// created through the meter; see OtelMeterOpts.CardinalityLimit. A
+	// warning is logged when an instrument reaches it. Zero means no limit.
+	CardinalityLimit int
+	// Exemplars selects the counter and histogram measurements exported with
+	// the trace and span ids of their context. Defaults to
+	// ExemplarFilterTraceBased.
+	Exemplars ExemplarFilter
+	// RuntimeMetrics registers the Go runtime metrics (goroutines, memory,
+	// GC pauses and scheduler latency) from runtime/metrics.
+	RuntimeMetrics bool
+	// ProcessMetrics registers the process metrics (CPU time, resident
+	// memory, open file descriptors and uptime).
+	ProcessMetrics bool
+	Log            log.Logger
+	Resource       telemetry.ResourceConfig
+	// DisableEnv stops the empty fields from being filled with the standard
+	// OTEL_* environment variables.
+	DisableEnv bool
 }
 
-func SetupOTelMetrics(ctx context.Context, cfg Config) (shutdown func(context.Context) error, err error) {
+// SetupOTelMetrics sets the global meter and meter provider, replacing the
+// ones of a previous setup, and serves the Prometheus metrics on a dedicated
+// HTTP server listening on Port. Bind errors are returned, leaving the globals
+// untouched, and the Shutdown method of the returned provider gracefully
+// stops the server.
+func SetupOTelMetrics(ctx context.Context, cfg Config) (MeterProvider, error) {
+	if cfg.Log == nil {
+		cfg.Log = log.Log()
+	}
 	if !cfg.Enabled {
 		cfg.Log.Info(ctx, "metrics disabled")
-		return func(ctx context.Context) error { return nil }, nil
-	}
-
-	res, err := resource.Merge(
-		resource.Default(),
-		resource.NewWithAttributes(
-			semconv.SchemaURL,
-			semconv.ServiceName(cfg.ApplicationName),
-			semconv.ServiceVersion(cfg.ApplicationVersion),
-			semconv.DeploymentEnvironmentName(cfg.Environment),
-		),
-	)
+		return NewNoOpMeterProvider(), nil
+	}
+
+	sdkProvider, handler, err := newMeterProvider(ctx, &cfg)
 	if err != nil {
 		return nil, err
 	}
+	if handler == nil {
+		return installMeterProvider(sdkProvider, cfg), nil
+	}
 
-	exporter, err := prometheus.New()
+	// The globals are installed once serving, so a bind error leaves the
+	// previous ones in place.
+	server, err := serveMetrics(ctx, cfg, handler)
 	if err != nil {
-		return nil, err
+		return nil, errors.Join(err, sdkProvider.Shutdown(ctx))
 	}
+	provider := installMeterProvider(sdkProvider, cfg)
+	provider.shutdown = func(ctx context.Context) error {
+		return errors.Join(server.Shutdown(ctx), sdkProvider.Shutdown(ctx))
+	}
+	return provider, nil
+}
 
-	meterProvider := sdkmetric.NewMeterProvider(
-		sdkmetric.WithResource(res),
-		sdkmetric.WithReader(exporter),
-	)
+// SetupOTelMetricsHandler sets the global meter and meter provider, as
+// SetupOTelMetrics does, and returns the handler exposing the Prometheus
+// metrics, to be mounted on an existing server, instead of starting one. Port
+// and Path are ignored.
+func SetupOTelMetricsHandler(ctx context.Context, cfg Config) (http.Handler, MeterProvider, error) {
+	if cfg.Log == nil {
+		cfg.Log = log.Log()
+	}
+	if !cfg.Enabled {
+		cfg.Log.Info(ctx, "metrics disabled")
+		return http.NotFoundHandler(), NewNoOpMeterProvider(), nil
+	}
 
-	SetMeter(NewOtelMeter(meterProvider.Meter(cfg.ApplicationName)))
-	shutdown = func(ctx context.Context) error {
-		return meterProvider.Shutdown(ctx)
+	sdkProvider, handler, err := newMeterProvider(ctx, &cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	if handler == nil {
+		return nil, nil, errors.Join(errPrometheusNotConfigured, sdkProvider.Shutdown(ctx))
 	}
+	return handler, installMeterProvider(sdkProvider, cfg), nil
+}
 
-	go serveMetrics(ctx, cfg)
+// newMeterProvider returns a provider exporting to the configured exporters.
+// The returned handler is nil unless Prometheus is one of them.
+func newMeterProvider(ctx context.Context, cfg *Config) (*sdkmetric.MeterProvider, http.Handler, error) {
+	newResource := telemetry.NewResource
+	if cfg.DisableEnv {
+		newResource = telemetry.NewResourceWithoutEnv
+	} else if err := cfg.applyEnv(); err != nil {
+		return nil, nil, err
+	}
 
-	return shutdown, nil
+	if cfg.CardinalityLimit < 0 {
+		return nil, nil, fmt.Errorf("negative metric cardinality limit %d", cfg.CardinalityLimit)
+	}
+	for _, view := range cfg.Views {
+		if err := view.validate(); err != nil {
+			return nil, nil, err
+		}
+	}
+	filter, err := exemplarFilter(cfg.Exemplars)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := newResource(ctx, telemetry.Service{
+		Name:        cfg.ApplicationName,
+		Version:     cfg.ApplicationVersion,
+		Environment: cfg.Environment,
+	}, cfg.Resource)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var producers []sdkmetric.Producer
+	if cfg.RuntimeMetrics {
+		producers = append(producers, newRuntimeHistogramProducer())
+	}
+	readers, handler, err := newReaders(ctx, *cfg, producers...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	opts := []sdkmetric.Option{sdkmetric.WithResource(res), sdkmetric.WithExemplarFilter(filter)}
+	for _, reader := range readers {
+		opts = append(opts, sdkmetric.WithReader(reader))
+	}
+	for _, view := range cfg.Views {
+		opts = append(opts, sdkmetric.WithView(view.sdkView()))
+	}
+	meterProvider := sdkmetric.NewMeterProvider(opts...)
+
+	if err := registerSystemMetrics(meterProvider, *cfg); err != nil {
+		return nil, nil, errors.Join(err, meterProvider.Shutdown(ctx))
+	}
+	startPushgatewayReaders(readers)
+
+	return meterProvider, handler, nil
+}
+
+// installMeterProvider sets the global OTel and toolkit meter providers, and
+// the global meter, named after the application. They replace the ones of a
+// previous setup, which may have been shut down.
+func installMeterProvider(sdkProvider *sdkmetric.MeterProvider, cfg Config) *OtelMeterProvider {
+	logger := cfg.Log
+	if logger == nil {
+		logger = log.Log()
+	}
+	provider := NewOtelMeterProviderWithOpts(sdkProvider, OtelMeterOpts{
+		CardinalityLimit: cfg.CardinalityLimit,
+		Views:            cfg.Views,
+		OnOverflow: func(ctx context.Context, instrument string, limit int) {
+			logger.Warn(ctx, "metric %s reached its cardinality limit of %d, new attribute sets are recorded with %s=true",
+				instrument, limit, OverflowAttributeKey)
+		},
+	})
+
+	otel.SetMeterProvider(sdkProvider)
+	ReplaceMeterProvider(provider)
+	ReplaceMeter(provider.Meter(cfg.ApplicationName))
+	return provider
 }
 
-func serveMetrics(ctx context.Context, cfg Config) {
-	cfg.Log.Info(ctx, fmt.Sprintf("serving metrics at port %d path %s", cfg.Port, cfg.Path))
-	http.Handle(cfg.Path, promhttp.Handler())
-	err := http.ListenAndServe(fmt.Sprintf(":%d", cfg.Port), nil)
+func registerSystemMetrics(provider otelmetric.MeterProvider, cfg Config) error {
+	meter := provider.Meter(instrumentationName)
+	if cfg.RuntimeMetrics {
+		if err := registerRuntimeMetrics(meter); err != nil {
+			return err
+		}
+	}
+	if cfg.ProcessMetrics {
+		if err := registerProcessMetrics(meter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func serveMetrics(ctx context.Context, cfg Config, handler http.Handler) (*http.Server, error) {
+	path := cfg.Path
+	if path == "" {
+		path = defaultMetricsPath
+	}
+	mux := http.NewServeMux()
+	mux.Handle(path, handler)
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Port))
 	if err != nil {
-		cfg.Log.Error(ctx, "error serving http", err)
-		return
+		return nil, fmt.Errorf("failed to listen on metrics port %d: %w", cfg.Port, err)
+	}
+
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
 	}
+	cfg.Log.Info(ctx, fmt.Sprintf("serving metrics at %s path %s", listener.Addr(), path))
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			cfg.Log.Error(ctx, "error serving metrics", err)
+		}
+	}()
+	return server, nil
 }
@@ -0,0 +1,142 @@
+package metric
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+)
+
+func freePort(t *testing.T) int {
+	t.Helper()
+	l, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatalf("failed to find a free port: %v", err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+func testConfig(port int) Config {
+	return Config{ApplicationName: "test-app", Enabled: true, Port: port, DisableEnv: true}
+}
+
+func TestSetupOTelMetricsHandler(t *testing.T) {
+	ctx := context.Background()
+	restoreGlobals(t)
+	handler, provider, err := SetupOTelMetricsHandler(ctx, testConfig(0))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer provider.Shutdown(ctx)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), "go_goroutines") {
+		t.Error("expected go runtime metrics to be exposed")
+	}
+}
+
+func TestSetupOTelMetrics_ServesAndShutsDown(t *testing.T) {
+	ctx := context.Background()
+	restoreGlobals(t)
+
+	// a second setup must not conflict with the first one
+	var urls []string
+	var providers []MeterProvider
+	for range 2 {
+		cfg := testConfig(freePort(t))
+		cfg.Path = "/custom"
+		provider, err := SetupOTelMetrics(ctx, cfg)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		urls = append(urls, fmt.Sprintf("http://localhost:%d/custom", cfg.Port))
+		providers = append(providers, provider)
+	}
+
+	for _, url := range urls {
+		resp, err := http.Get(url)
+		if err != nil {
+			t.Fatalf("failed to get metrics: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "go_goroutines") {
+			t.Errorf("unexpected response %d: %s", resp.StatusCode, body)
+		}
+	}
+
+	for _, provider := range providers {
+		if err := provider.Shutdown(ctx); err != nil {
+			t.Fatalf("failed to shutdown: %v", err)
+		}
+	}
+	if _, err := http.Get(urls[0]); err == nil {
+		t.Error("expected server to be stopped after shutdown")
+	}
+}
+
+func TestSetupOTelMetrics_ReturnsBindError(t *testing.T) {
+	l, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer l.Close()
+
+	restoreGlobals(t)
+	meter, provider := GetMeter(), GetMeterProvider()
+
+	_, err = SetupOTelMetrics(context.Background(), testConfig(l.Addr().(*net.TCPAddr).Port))
+	if err == nil {
+		t.Error("expected error when the port is in use")
+	}
+	if GetMeter() != meter || GetMeterProvider() != provider {
+		t.Error("expected the globals to be left untouched")
+	}
+}
+
+func TestSetupOTelMetrics_ReplacesPreviousSetup(t *testing.T) {
+	ctx := context.Background()
+	restoreGlobals(t)
+
+	first, err := SetupOTelMetrics(ctx, testConfig(freePort(t)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := first.Shutdown(ctx); err != nil {
+		t.Fatalf("failed to shutdown: %v", err)
+	}
+
+	second, err := SetupOTelMetrics(ctx, testConfig(freePort(t)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer second.Shutdown(ctx)
+	if GetMeterProvider() != second {
+		t.Error("expected the second setup to replace the global meter provider")
+	}
+	if GetMeter() != second.Meter("test-app") {
+		t.Error("expected the second setup to replace the global meter")
+	}
+}
+
+// restoreGlobals restores the global meter and meter provider when the test
+// finishes.
+// restoreGlobals restores the global meters and meter providers, replaced by
+// the setups of the test, when it finishes.
+func restoreGlobals(t *testing.T) {
+	otelProvider := otel.GetMeterProvider()
+	t.Cleanup(func() { otel.SetMeterProvider(otelProvider) })
+	t.Cleanup(ReplaceMeter(GetMeter()))
+	t.Cleanup(ReplaceMeterProvider(GetMeterProvider()))
+}
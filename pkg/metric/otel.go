@@ -3,47 +3,489 @@ package metric
 import (
 	"context"
 	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 )
 
-type OtelMeter struct {
-	meter metric.Meter
-}
+type (
+	// OtelMeter records measurements with an OTel meter. Instruments are
+	// registered once per name and reused for the following calls; declaring
+	// a name again with a different kind, description, unit or buckets returns
+	// a *ConflictError.
+	OtelMeter struct {
+		meter            metric.Meter
+		strict           bool
+		cardinalityLimit int
+		views            []View
+		onOverflow       func(ctx context.Context, instrument string, limit int)
+		mu               sync.Mutex
+		instruments      sync.Map
+		// scope and declarations are set by OtelMeterProvider, whose meters
+		// share declarations to detect conflicts across scopes.
+		scope        string
+		declarations *sync.Map
+	}
+	OtelMeterOpts struct {
+		// Strict makes conflicting instrument declarations panic instead of
+		// returning a *ConflictError.
+		Strict bool
+		// CardinalityLimit caps the number of attribute sets recorded by each
+		// instrument, the overflow series included. The measurements of new
+		// sets beyond it are recorded with the otel.metric.overflow attribute.
+		// The sets are counted since the creation of the instrument, whatever
+		// the temporality: unlike the provider-wide limit of the SDK, this one
+		// applies per instrument and reports the overflow with OnOverflow.
+		// Zero means no limit.
+		CardinalityLimit int
+		// Views override the limit and filter the attributes counted against
+		// it for the matching instruments. They are applied to the exported
+		// streams by Config.Views.
+		Views []View
+		// OnOverflow is called once per instrument when its limit is reached.
+		OnOverflow func(ctx context.Context, instrument string, limit int)
+	}
 
-var _ Meter = (*OtelMeter)(nil)
+	registeredInstrument struct {
+		info       InstrumentInfo
+		instrument any
+	}
+
+	otelAdder[N int64 | float64] struct {
+		instrument interface {
+			Add(ctx context.Context, incr N, options ...metric.AddOption)
+		}
+		limiter *cardinalityLimiter
+		// monotonic rejects the negative values of counters, reported with
+		// name.
+		monotonic bool
+		name      string
+	}
+	otelRecorder[N int64 | float64] struct {
+		instrument interface {
+			Record(ctx context.Context, value N, options ...metric.RecordOption)
+		}
+		limiter *cardinalityLimiter
+	}
+)
+
+var (
+	_ Meter              = (*OtelMeter)(nil)
+	_ InstrumentRegistry = (*OtelMeter)(nil)
+)
 
 func NewOtelMeter(meter metric.Meter) *OtelMeter {
 	return &OtelMeter{meter: meter}
 }
 
+func NewOtelMeterWithOpts(meter metric.Meter, opts OtelMeterOpts) *OtelMeter {
+	return &OtelMeter{
+		meter:            meter,
+		strict:           opts.Strict,
+		cardinalityLimit: opts.CardinalityLimit,
+		views:            opts.Views,
+		onOverflow:       opts.OnOverflow,
+	}
+}
+
+func (m *OtelMeter) Counter(name string, opts InstrumentOpts) (Counter, error) {
+	info := newInstrumentInfo(InstrumentCounter, name, opts)
+	return registerInstrument(m, info, func() (Counter, error) {
+		counter, err := m.meter.Float64Counter(name, metric.WithDescription(opts.Description), metric.WithUnit(opts.Unit))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create counter %s: %w", name, err)
+		}
+		return otelAdder[float64]{instrument: counter, limiter: m.newCardinalityLimiter(info), monotonic: true, name: name}, nil
+	})
+}
+
+func (m *OtelMeter) Int64Counter(name string, opts InstrumentOpts) (Int64Counter, error) {
+	info := newInstrumentInfo(InstrumentInt64Counter, name, opts)
+	return registerInstrument(m, info, func() (Int64Counter, error) {
+		counter, err := m.meter.Int64Counter(name, metric.WithDescription(opts.Description), metric.WithUnit(opts.Unit))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create counter %s: %w", name, err)
+		}
+		return otelAdder[int64]{instrument: counter, limiter: m.newCardinalityLimiter(info), monotonic: true, name: name}, nil
+	})
+}
+
+func (m *OtelMeter) UpDownCounter(name string, opts InstrumentOpts) (UpDownCounter, error) {
+	info := newInstrumentInfo(InstrumentUpDownCounter, name, opts)
+	return registerInstrument(m, info, func() (UpDownCounter, error) {
+		upDownCounter, err := m.meter.Float64UpDownCounter(name, metric.WithDescription(opts.Description), metric.WithUnit(opts.Unit))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create up-down counter %s: %w", name, err)
+		}
+		return otelAdder[float64]{instrument: upDownCounter, limiter: m.newCardinalityLimiter(info)}, nil
+	})
+}
+
+func (m *OtelMeter) Int64UpDownCounter(name string, opts InstrumentOpts) (Int64UpDownCounter, error) {
+	info := newInstrumentInfo(InstrumentInt64UpDownCounter, name, opts)
+	return registerInstrument(m, info, func() (Int64UpDownCounter, error) {
+		upDownCounter, err := m.meter.Int64UpDownCounter(name, metric.WithDescription(opts.Description), metric.WithUnit(opts.Unit))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create up-down counter %s: %w", name, err)
+		}
+		return otelAdder[int64]{instrument: upDownCounter, limiter: m.newCardinalityLimiter(info)}, nil
+	})
+}
+
+func (m *OtelMeter) Gauge(name string, opts InstrumentOpts) (Gauge, error) {
+	info := newInstrumentInfo(InstrumentGauge, name, opts)
+	return registerInstrument(m, info, func() (Gauge, error) {
+		gauge, err := m.meter.Float64Gauge(name, metric.WithDescription(opts.Description), metric.WithUnit(opts.Unit))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create gauge %s: %w", name, err)
+		}
+		return otelRecorder[float64]{instrument: gauge, limiter: m.newCardinalityLimiter(info)}, nil
+	})
+}
+
+func (m *OtelMeter) Int64Gauge(name string, opts InstrumentOpts) (Int64Gauge, error) {
+	info := newInstrumentInfo(InstrumentInt64Gauge, name, opts)
+	return registerInstrument(m, info, func() (Int64Gauge, error) {
+		gauge, err := m.meter.Int64Gauge(name, metric.WithDescription(opts.Description), metric.WithUnit(opts.Unit))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create gauge %s: %w", name, err)
+		}
+		return otelRecorder[int64]{instrument: gauge, limiter: m.newCardinalityLimiter(info)}, nil
+	})
+}
+
+func (m *OtelMeter) Histogram(name string, opts InstrumentOpts) (Histogram, error) {
+	info := newInstrumentInfo(InstrumentHistogram, name, opts)
+	return registerInstrument(m, info, func() (Histogram, error) {
+		histOpts := []metric.Float64HistogramOption{metric.WithDescription(opts.Description), metric.WithUnit(opts.Unit)}
+		if len(opts.Buckets) > 0 {
+			histOpts = append(histOpts, metric.WithExplicitBucketBoundaries(opts.Buckets...))
+		}
+		histogram, err := m.meter.Float64Histogram(name, histOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create histogram %s: %w", name, err)
+		}
+		return otelRecorder[float64]{instrument: histogram, limiter: m.newCardinalityLimiter(info)}, nil
+	})
+}
+
+func (m *OtelMeter) Int64Histogram(name string, opts InstrumentOpts) (Int64Histogram, error) {
+	info := newInstrumentInfo(InstrumentInt64Histogram, name, opts)
+	return registerInstrument(m, info, func() (Int64Histogram, error) {
+		histOpts := []metric.Int64HistogramOption{metric.WithDescription(opts.Description), metric.WithUnit(opts.Unit)}
+		if len(opts.Buckets) > 0 {
+			histOpts = append(histOpts, metric.WithExplicitBucketBoundaries(opts.Buckets...))
+		}
+		histogram, err := m.meter.Int64Histogram(name, histOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create histogram %s: %w", name, err)
+		}
+		return otelRecorder[int64]{instrument: histogram, limiter: m.newCardinalityLimiter(info)}, nil
+	})
+}
+
+func (m *OtelMeter) AddCounter(ctx context.Context, name string, description string, unit string, value float64, attrs ...Attribute) error {
+	if value < 0 {
+		return fmt.Errorf("failed to add to counter %s: %w", name, ErrNegativeCounterValue)
+	}
+	counter, err := m.Counter(name, InstrumentOpts{Description: description, Unit: unit})
+	if err != nil {
+		return err
+	}
+	counter.Add(ctx, value, attrs...)
+	return nil
+}
+
+func (m *OtelMeter) AddInt64Counter(ctx context.Context, name string, description string, unit string, value int64, attrs ...Attribute) error {
+	if value < 0 {
+		return fmt.Errorf("failed to add to counter %s: %w", name, ErrNegativeCounterValue)
+	}
+	counter, err := m.Int64Counter(name, InstrumentOpts{Description: description, Unit: unit})
+	if err != nil {
+		return err
+	}
+	counter.Add(ctx, value, attrs...)
+	return nil
+}
+
+func (m *OtelMeter) AddUpDownCounter(ctx context.Context, name string, description string, unit string, value float64, attrs ...Attribute) error {
+	upDownCounter, err := m.UpDownCounter(name, InstrumentOpts{Description: description, Unit: unit})
+	if err != nil {
+		return err
+	}
+	upDownCounter.Add(ctx, value, attrs...)
+	return nil
+}
+
+func (m *OtelMeter) AddInt64UpDownCounter(ctx context.Context, name string, description string, unit string, value int64, attrs ...Attribute) error {
+	upDownCounter, err := m.Int64UpDownCounter(name, InstrumentOpts{Description: description, Unit: unit})
+	if err != nil {
+		return err
+	}
+	upDownCounter.Add(ctx, value, attrs...)
+	return nil
+}
+
 func (m *OtelMeter) AddGauge(ctx context.Context, name string, description string, unit string, value float64, attrs ...Attribute) error {
-	gauge, err := m.meter.Float64Gauge(
-		name,
-		metric.WithDescription(description),
-		metric.WithUnit(unit),
-	)
+	gauge, err := m.Gauge(name, InstrumentOpts{Description: description, Unit: unit})
 	if err != nil {
-		return fmt.Errorf("failed to create gauge %s: %w", name, err)
+		return err
 	}
-	gauge.Record(ctx, value, metric.WithAttributes(toOtelAttributes(attrs)...))
+	gauge.Record(ctx, value, attrs...)
 	return nil
 }
 
-func (m *OtelMeter) AddCounter(ctx context.Context, name string, description string, unit string, value float64, attrs ...Attribute) error {
-	upDownCounter, err := m.meter.Float64UpDownCounter(
-		name,
-		metric.WithDescription(description),
-		metric.WithUnit(unit),
-	)
+func (m *OtelMeter) AddInt64Gauge(ctx context.Context, name string, description string, unit string, value int64, attrs ...Attribute) error {
+	gauge, err := m.Int64Gauge(name, InstrumentOpts{Description: description, Unit: unit})
+	if err != nil {
+		return err
+	}
+	gauge.Record(ctx, value, attrs...)
+	return nil
+}
+
+// RecordHistogram records value in the histogram name. The buckets of the
+// first call are kept for the following ones.
+func (m *OtelMeter) RecordHistogram(ctx context.Context, name string, description string, unit string, buckets []float64, value float64, attrs ...Attribute) error {
+	histogram, err := m.Histogram(name, InstrumentOpts{Description: description, Unit: unit, Buckets: buckets})
+	if err != nil {
+		return err
+	}
+	histogram.Record(ctx, value, attrs...)
+	return nil
+}
+
+func (m *OtelMeter) RecordInt64Histogram(ctx context.Context, name string, description string, unit string, buckets []float64, value int64, attrs ...Attribute) error {
+	histogram, err := m.Int64Histogram(name, InstrumentOpts{Description: description, Unit: unit, Buckets: buckets})
 	if err != nil {
-		return fmt.Errorf("failed to create up-down counter %s: %w", name, err)
+		return err
 	}
-	upDownCounter.Add(ctx, value, metric.WithAttributes(toOtelAttributes(attrs)...))
+	histogram.Record(ctx, value, attrs...)
 	return nil
 }
 
+func (m *OtelMeter) ObserveGauge(name string, description string, unit string, fn ObserveFunc) (func() error, error) {
+	info := newInstrumentInfo(InstrumentObservableGauge, name, InstrumentOpts{Description: description, Unit: unit})
+	instrument, err := registerInstrument(m, info, func() (metric.Float64Observable, error) {
+		instrument, err := m.meter.Float64ObservableGauge(
+			name,
+			metric.WithDescription(description),
+			metric.WithUnit(unit),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create observable gauge %s: %w", name, err)
+		}
+		return instrument, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m.registerCallback(info, instrument, fn)
+}
+
+func (m *OtelMeter) ObserveCounter(name string, description string, unit string, fn ObserveFunc) (func() error, error) {
+	info := newInstrumentInfo(InstrumentObservableCounter, name, InstrumentOpts{Description: description, Unit: unit})
+	instrument, err := registerInstrument(m, info, func() (metric.Float64Observable, error) {
+		instrument, err := m.meter.Float64ObservableCounter(
+			name,
+			metric.WithDescription(description),
+			metric.WithUnit(unit),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create observable counter %s: %w", name, err)
+		}
+		return instrument, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m.registerCallback(info, instrument, fn)
+}
+
+func (m *OtelMeter) ObserveUpDownCounter(name string, description string, unit string, fn ObserveFunc) (func() error, error) {
+	info := newInstrumentInfo(InstrumentObservableUpDownCounter, name, InstrumentOpts{Description: description, Unit: unit})
+	instrument, err := registerInstrument(m, info, func() (metric.Float64Observable, error) {
+		instrument, err := m.meter.Float64ObservableUpDownCounter(
+			name,
+			metric.WithDescription(description),
+			metric.WithUnit(unit),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create observable up-down counter %s: %w", name, err)
+		}
+		return instrument, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m.registerCallback(info, instrument, fn)
+}
+
+func (m *OtelMeter) registerCallback(info InstrumentInfo, instrument metric.Float64Observable, fn ObserveFunc) (func() error, error) {
+	limiter := m.newCardinalityLimiter(info)
+	registration, err := m.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		return fn(ctx, otelObserver{ctx: ctx, observer: o, instrument: instrument, limiter: limiter})
+	}, instrument)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register callback for %s: %w", info.Name, err)
+	}
+	return registration.Unregister, nil
+}
+
+type otelObserver struct {
+	ctx        context.Context
+	observer   metric.Observer
+	instrument metric.Float64Observable
+	limiter    *cardinalityLimiter
+}
+
+func (o otelObserver) Observe(value float64, attrs ...Attribute) {
+	if o.limiter == nil {
+		o.observer.ObserveFloat64(o.instrument, value, metric.WithAttributes(toOtelAttributes(attrs)...))
+		return
+	}
+	o.observer.ObserveFloat64(o.instrument, value, metric.WithAttributeSet(limitedAttributeSet(o.ctx, o.limiter, attrs)))
+}
+
+// Instruments returns the instruments registered in this meter, sorted by name.
+func (m *OtelMeter) Instruments() []InstrumentInfo {
+	var infos []InstrumentInfo
+	m.instruments.Range(func(_, value any) bool {
+		infos = append(infos, value.(registeredInstrument).info)
+		return true
+	})
+	sortInstruments(infos)
+	return infos
+}
+
+// registerInstrument returns the instrument registered with info.Name,
+// creating it on the first call, or a *ConflictError when it was registered
+// with a different declaration.
+func registerInstrument[T any](m *OtelMeter, info InstrumentInfo, create func() (T, error)) (T, error) {
+	info.Scope = m.scope
+	if registered, ok := m.instruments.Load(info.Name); ok {
+		return existingInstrument[T](m, registered.(registeredInstrument), info)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if registered, ok := m.instruments.Load(info.Name); ok {
+		return existingInstrument[T](m, registered.(registeredInstrument), info)
+	}
+
+	var zero T
+	if err := m.checkViews(info); err != nil {
+		return zero, err
+	}
+	declared := false
+	if m.declarations != nil {
+		previous, loaded := m.declarations.LoadOrStore(info.Name, info)
+		if loaded && previous.(InstrumentInfo).conflicts(info) {
+			return zero, m.conflict(previous.(InstrumentInfo), info)
+		}
+		declared = !loaded
+	}
+	instrument, err := create()
+	if err != nil {
+		if declared {
+			m.declarations.Delete(info.Name)
+		}
+		return instrument, err
+	}
+	m.instruments.Store(info.Name, registeredInstrument{info: info, instrument: instrument})
+	return instrument, nil
+}
+
+func existingInstrument[T any](m *OtelMeter, registered registeredInstrument, requested InstrumentInfo) (T, error) {
+	if registered.info.conflicts(requested) {
+		var zero T
+		return zero, m.conflict(registered.info, requested)
+	}
+	return registered.instrument.(T), nil
+}
+
+// conflict returns the *ConflictError of requested, or panics with it when m
+// is strict.
+func (m *OtelMeter) conflict(registered, requested InstrumentInfo) error {
+	err := &ConflictError{Registered: registered, Requested: requested}
+	if m.strict {
+		panic(err)
+	}
+	return err
+}
+
+func (a otelAdder[N]) Add(ctx context.Context, value N, attrs ...Attribute) {
+	if a.rejects(value) {
+		return
+	}
+	if len(attrs) == 0 && a.limiter == nil {
+		a.instrument.Add(ctx, value)
+		return
+	}
+	a.instrument.Add(ctx, value, metric.WithAttributeSet(limitedAttributeSet(ctx, a.limiter, attrs)))
+}
+
+func (a otelAdder[N]) AddSet(ctx context.Context, value N, set AttributeSet) {
+	if a.rejects(value) {
+		return
+	}
+	a.instrument.Add(ctx, value, set.measurementOption(ctx, a.limiter))
+}
+
+// rejects reports negative counter values to the OTel error handler, as the
+// handles return no error.
+func (a otelAdder[N]) rejects(value N) bool {
+	if !a.monotonic || value >= 0 {
+		return false
+	}
+	otel.Handle(fmt.Errorf("failed to add to counter %s: %w", a.name, ErrNegativeCounterValue))
+	return true
+}
+
+func (r otelRecorder[N]) Record(ctx context.Context, value N, attrs ...Attribute) {
+	if len(attrs) == 0 && r.limiter == nil {
+		r.instrument.Record(ctx, value)
+		return
+	}
+	r.instrument.Record(ctx, value, metric.WithAttributeSet(limitedAttributeSet(ctx, r.limiter, attrs)))
+}
+
+func (r otelRecorder[N]) RecordSet(ctx context.Context, value N, set AttributeSet) {
+	r.instrument.Record(ctx, value, set.measurementOption(ctx, r.limiter))
+}
+
+var attributesPool = sync.Pool{
+	New: func() any {
+		s := make([]attribute.KeyValue, 0, 8)
+		return &s
+	},
+}
+
+// toAttributeSet converts attrs using a pooled buffer, which is safe to reuse
+// because the set keeps its own copy of the attributes.
+func toAttributeSet(attrs []Attribute) attribute.Set {
+	buf := attributesPool.Get().(*[]attribute.KeyValue)
+	kvs := (*buf)[:0]
+	for _, attr := range attrs {
+		kvs = append(kvs, toOtelAttribute(attr))
+	}
+	set := attribute.NewSet(kvs...)
+	*buf = kvs[:0]
+	attributesPool.Put(buf)
+	return set
+}
+
+// OtelAttributeSet returns the OTel attribute set OtelMeter records for attrs.
+func OtelAttributeSet(attrs ...Attribute) attribute.Set {
+	return toAttributeSet(attrs)
+}
+
 func toOtelAttributes(attrs []Attribute) []attribute.KeyValue {
 	if len(attrs) == 0 {
 		return nil
@@ -62,8 +504,26 @@ func toOtelAttribute(attr Attribute) attribute.KeyValue {
 		return attribute.String(attr.Key, v)
 	case int:
 		return attribute.Int(attr.Key, v)
+	case int8:
+		return attribute.Int64(attr.Key, int64(v))
+	case int16:
+		return attribute.Int64(attr.Key, int64(v))
+	case int32:
+		return attribute.Int64(attr.Key, int64(v))
 	case int64:
 		return attribute.Int64(attr.Key, v)
+	case uint:
+		return uintAttribute(attr.Key, uint64(v))
+	case uint8:
+		return attribute.Int64(attr.Key, int64(v))
+	case uint16:
+		return attribute.Int64(attr.Key, int64(v))
+	case uint32:
+		return attribute.Int64(attr.Key, int64(v))
+	case uint64:
+		return uintAttribute(attr.Key, v)
+	case float32:
+		return attribute.Float64(attr.Key, float64(v))
 	case float64:
 		return attribute.Float64(attr.Key, v)
 	case bool:
@@ -78,8 +538,33 @@ func toOtelAttribute(attr Attribute) attribute.KeyValue {
 		return attribute.Float64Slice(attr.Key, v)
 	case []bool:
 		return attribute.BoolSlice(attr.Key, v)
+	case time.Duration:
+		return attribute.String(attr.Key, v.String())
+	case fmt.Stringer:
+		return attribute.String(attr.Key, v.String())
+	case error:
+		return attribute.String(attr.Key, v.Error())
 	default:
-
+		reportUnsupportedAttribute(attr)
 		return attribute.String(attr.Key, fmt.Sprintf("%v", v))
 	}
 }
+
+// uintAttribute records values beyond the int64 range as strings.
+func uintAttribute(key string, v uint64) attribute.KeyValue {
+	if v > math.MaxInt64 {
+		return attribute.String(key, strconv.FormatUint(v, 10))
+	}
+	return attribute.Int64(key, int64(v))
+}
+
+var unsupportedAttributeTypes sync.Map
+
+// reportUnsupportedAttribute reports once per type, to the OTel error
+// handler, values converted with fmt because they have no attribute type.
+func reportUnsupportedAttribute(attr Attribute) {
+	t := reflect.TypeOf(attr.Value)
+	if _, reported := unsupportedAttributeTypes.LoadOrStore(t, struct{}{}); !reported {
+		otel.Handle(fmt.Errorf("metric attribute %s has the unsupported type %v and is recorded as a string", attr.Key, t))
+	}
+}
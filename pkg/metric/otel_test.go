@@ -0,0 +1,321 @@
+package metric
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func newTestOtelMeter(t *testing.T) (*OtelMeter, *sdkmetric.ManualReader) {
+	t.Helper()
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+	return NewOtelMeter(provider.Meter("test")), reader
+}
+
+func collect(t *testing.T, reader *sdkmetric.ManualReader) map[string]metricdata.Aggregation {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("failed to collect metrics: %v", err)
+	}
+	result := make(map[string]metricdata.Aggregation)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			result[m.Name] = m.Data
+		}
+	}
+	return result
+}
+
+func TestOtelMeter_Counters(t *testing.T) {
+	ctx := context.Background()
+	meter, reader := newTestOtelMeter(t)
+
+	if err := meter.AddCounter(ctx, "requests", "Requests", "1", 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := meter.AddInt64Counter(ctx, "bytes", "Bytes", "By", 512); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := meter.AddUpDownCounter(ctx, "connections", "Connections", "1", -1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := meter.AddInt64UpDownCounter(ctx, "queue.size", "Queue size", "1", -3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data := collect(t, reader)
+	if sum, ok := data["requests"].(metricdata.Sum[float64]); !ok || !sum.IsMonotonic || sum.DataPoints[0].Value != 2 {
+		t.Errorf("expected monotonic float64 sum of 2, got %+v", data["requests"])
+	}
+	if sum, ok := data["bytes"].(metricdata.Sum[int64]); !ok || !sum.IsMonotonic || sum.DataPoints[0].Value != 512 {
+		t.Errorf("expected monotonic int64 sum of 512, got %+v", data["bytes"])
+	}
+	if sum, ok := data["connections"].(metricdata.Sum[float64]); !ok || sum.IsMonotonic || sum.DataPoints[0].Value != -1 {
+		t.Errorf("expected non monotonic float64 sum of -1, got %+v", data["connections"])
+	}
+	if sum, ok := data["queue.size"].(metricdata.Sum[int64]); !ok || sum.IsMonotonic || sum.DataPoints[0].Value != -3 {
+		t.Errorf("expected non monotonic int64 sum of -3, got %+v", data["queue.size"])
+	}
+}
+
+func TestOtelMeter_CounterRejectsNegativeValues(t *testing.T) {
+	meter, _ := newTestOtelMeter(t)
+
+	if err := meter.AddCounter(context.Background(), "requests", "Requests", "1", -1); !errors.Is(err, ErrNegativeCounterValue) {
+		t.Errorf("expected ErrNegativeCounterValue, got %v", err)
+	}
+	if err := meter.AddInt64Counter(context.Background(), "requests.int", "Requests", "1", -1); !errors.Is(err, ErrNegativeCounterValue) {
+		t.Errorf("expected ErrNegativeCounterValue, got %v", err)
+	}
+}
+
+func TestOtelMeter_Gauges(t *testing.T) {
+	ctx := context.Background()
+	meter, reader := newTestOtelMeter(t)
+
+	_ = meter.AddGauge(ctx, "temperature", "Temperature", "Cel", 21.5)
+	_ = meter.AddInt64Gauge(ctx, "goroutines", "Goroutines", "1", 12)
+
+	data := collect(t, reader)
+	if g, ok := data["temperature"].(metricdata.Gauge[float64]); !ok || g.DataPoints[0].Value != 21.5 {
+		t.Errorf("expected float64 gauge of 21.5, got %+v", data["temperature"])
+	}
+	if g, ok := data["goroutines"].(metricdata.Gauge[int64]); !ok || g.DataPoints[0].Value != 12 {
+		t.Errorf("expected int64 gauge of 12, got %+v", data["goroutines"])
+	}
+}
+
+func TestOtelMeter_Histograms(t *testing.T) {
+	ctx := context.Background()
+	meter, reader := newTestOtelMeter(t)
+	buckets := []float64{0.1, 0.5, 1}
+
+	for _, v := range []float64{0.05, 0.3, 2} {
+		if err := meter.RecordHistogram(ctx, "latency", "Latency", "s", buckets, v); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := meter.RecordInt64Histogram(ctx, "payload", "Payload", "By", nil, 100); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data := collect(t, reader)
+	h, ok := data["latency"].(metricdata.Histogram[float64])
+	if !ok {
+		t.Fatalf("expected float64 histogram, got %T", data["latency"])
+	}
+	dp := h.DataPoints[0]
+	if len(dp.Bounds) != len(buckets) {
+		t.Errorf("expected bounds %v, got %v", buckets, dp.Bounds)
+	}
+	expectedCounts := []uint64{1, 1, 0, 1}
+	for i, c := range expectedCounts {
+		if dp.BucketCounts[i] != c {
+			t.Errorf("expected bucket counts %v, got %v", expectedCounts, dp.BucketCounts)
+			break
+		}
+	}
+	if ih, ok := data["payload"].(metricdata.Histogram[int64]); !ok || ih.DataPoints[0].Count != 1 {
+		t.Errorf("expected int64 histogram with 1 value, got %+v", data["payload"])
+	}
+}
+
+func TestOtelMeter_Observables(t *testing.T) {
+	meter, reader := newTestOtelMeter(t)
+
+	unregister, err := meter.ObserveGauge("pool.idle", "Idle connections", "1", func(ctx context.Context, o Observer) error {
+		o.Observe(4, NewAttribute("pool", "primary"))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := meter.ObserveCounter("cpu.time", "CPU time", "s", func(ctx context.Context, o Observer) error {
+		o.Observe(1.5)
+		return nil
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := meter.ObserveUpDownCounter("inflight", "In flight", "1", func(ctx context.Context, o Observer) error {
+		o.Observe(-2)
+		return nil
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data := collect(t, reader)
+	if g, ok := data["pool.idle"].(metricdata.Gauge[float64]); !ok || g.DataPoints[0].Value != 4 {
+		t.Errorf("expected observed gauge of 4, got %+v", data["pool.idle"])
+	}
+	if s, ok := data["cpu.time"].(metricdata.Sum[float64]); !ok || !s.IsMonotonic || s.DataPoints[0].Value != 1.5 {
+		t.Errorf("expected observed monotonic sum of 1.5, got %+v", data["cpu.time"])
+	}
+	if s, ok := data["inflight"].(metricdata.Sum[float64]); !ok || s.IsMonotonic || s.DataPoints[0].Value != -2 {
+		t.Errorf("expected observed sum of -2, got %+v", data["inflight"])
+	}
+
+	if err := unregister(); err != nil {
+		t.Fatalf("failed to unregister: %v", err)
+	}
+	if g, ok := collect(t, reader)["pool.idle"].(metricdata.Gauge[float64]); ok && len(g.DataPoints) > 0 {
+		t.Errorf("expected no data points after unregister, got %+v", g.DataPoints)
+	}
+}
+
+func TestOtelMeter_Handles(t *testing.T) {
+	ctx := context.Background()
+	meter, reader := newTestOtelMeter(t)
+
+	counter, err := meter.Counter("requests", InstrumentOpts{Description: "Requests", Unit: "1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	histogram, err := meter.Int64Histogram("payload", InstrumentOpts{Unit: "By", Buckets: []float64{100, 1000}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	counter.Add(ctx, 1, NewAttribute("method", "GET"))
+	counter.Add(ctx, 2, NewAttribute("method", "GET"))
+	histogram.Record(ctx, 500)
+
+	data := collect(t, reader)
+	if sum, ok := data["requests"].(metricdata.Sum[float64]); !ok || sum.DataPoints[0].Value != 3 {
+		t.Errorf("expected sum of 3, got %+v", data["requests"])
+	}
+	if h, ok := data["payload"].(metricdata.Histogram[int64]); !ok || h.DataPoints[0].BucketCounts[1] != 1 {
+		t.Errorf("expected value in the second bucket, got %+v", data["payload"])
+	}
+}
+
+func TestOtelMeter_CounterHandlesDropNegativeValues(t *testing.T) {
+	var reported []error
+	previous := otel.GetErrorHandler()
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) { reported = append(reported, err) }))
+	t.Cleanup(func() { otel.SetErrorHandler(previous) })
+
+	ctx := context.Background()
+	meter, reader := newTestOtelMeter(t)
+	counter, _ := meter.Counter("requests", InstrumentOpts{})
+	int64Counter, _ := meter.Int64Counter("requests.int", InstrumentOpts{})
+	upDownCounter, _ := meter.UpDownCounter("connections", InstrumentOpts{})
+
+	counter.Add(ctx, 2)
+	counter.Add(ctx, -1)
+	counter.AddSet(ctx, -1, NewAttributeSet())
+	int64Counter.Add(ctx, -1)
+	upDownCounter.Add(ctx, -1)
+
+	data := collect(t, reader)
+	if sum := data["requests"].(metricdata.Sum[float64]); sum.DataPoints[0].Value != 2 {
+		t.Errorf("expected the negative values to be dropped, got %v", sum.DataPoints[0].Value)
+	}
+	if sum := data["connections"].(metricdata.Sum[float64]); sum.DataPoints[0].Value != -1 {
+		t.Errorf("expected up-down counters to accept negative values, got %v", sum.DataPoints[0].Value)
+	}
+	if len(reported) != 3 || !errors.Is(reported[0], ErrNegativeCounterValue) {
+		t.Errorf("expected 3 reports of ErrNegativeCounterValue, got %v", reported)
+	}
+}
+
+func TestOtelMeter_CachesInstruments(t *testing.T) {
+	ctx := context.Background()
+	meter, reader := newTestOtelMeter(t)
+
+	handle, err := meter.Counter("requests", InstrumentOpts{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	same, _ := meter.Counter("requests", InstrumentOpts{})
+	if handle != same {
+		t.Error("expected the same handle for the same name")
+	}
+
+	handle.Add(ctx, 1)
+	_ = meter.AddCounter(ctx, "requests", "", "", 1)
+
+	count := 0
+	meter.instruments.Range(func(key, value any) bool {
+		count++
+		return true
+	})
+	if count != 1 {
+		t.Errorf("expected 1 cached instrument, got %d", count)
+	}
+	if sum, ok := collect(t, reader)["requests"].(metricdata.Sum[float64]); !ok || sum.DataPoints[0].Value != 2 {
+		t.Errorf("expected handle and convenience API to share the counter")
+	}
+}
+
+func BenchmarkOtelMeter_CreateOnEveryCall(b *testing.B) {
+	ctx := context.Background()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewManualReader()))
+	defer provider.Shutdown(ctx)
+	meter := provider.Meter("bench")
+	attrs := []Attribute{NewAttribute("method", "GET")}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		counter, _ := meter.Float64Counter("requests", otelmetric.WithDescription("Requests"), otelmetric.WithUnit("1"))
+		counter.Add(ctx, 1, otelmetric.WithAttributes(toOtelAttributes(attrs)...))
+	}
+}
+
+func BenchmarkOtelMeter_AddCounter(b *testing.B) {
+	ctx := context.Background()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewManualReader()))
+	defer provider.Shutdown(ctx)
+	meter := NewOtelMeter(provider.Meter("bench"))
+	attr := NewAttribute("method", "GET")
+
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = meter.AddCounter(ctx, "requests", "Requests", "1", 1, attr)
+	}
+}
+
+func BenchmarkOtelMeter_CounterHandle(b *testing.B) {
+	ctx := context.Background()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewManualReader()))
+	defer provider.Shutdown(ctx)
+	counter, _ := NewOtelMeter(provider.Meter("bench")).Counter("requests", InstrumentOpts{Description: "Requests", Unit: "1"})
+	attr := NewAttribute("method", "GET")
+
+	b.ReportAllocs()
+	for b.Loop() {
+		counter.Add(ctx, 1, attr)
+	}
+}
+
+func BenchmarkOtelMeter_CounterHandleNoAttributes(b *testing.B) {
+	ctx := context.Background()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewManualReader()))
+	defer provider.Shutdown(ctx)
+	counter, _ := NewOtelMeter(provider.Meter("bench")).Counter("requests", InstrumentOpts{Description: "Requests", Unit: "1"})
+
+	b.ReportAllocs()
+	for b.Loop() {
+		counter.Add(ctx, 1)
+	}
+}
+
+func BenchmarkOtelMeter_CounterHandleAttributeSet(b *testing.B) {
+	ctx := context.Background()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewManualReader()))
+	defer provider.Shutdown(ctx)
+	counter, _ := NewOtelMeter(provider.Meter("bench")).Counter("requests", InstrumentOpts{Description: "Requests", Unit: "1"})
+	set := NewAttributeSet(String("method", "GET"))
+
+	b.ReportAllocs()
+	for b.Loop() {
+		counter.AddSet(ctx, 1, set)
+	}
+}
@@ -0,0 +1,68 @@
+package metric
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/semconv/v1.37.0/processconv"
+)
+
+var processStart = time.Now()
+
+// registerProcessMetrics registers observable instruments for the process
+// metrics, following the process.* semantic conventions. Metrics not
+// available on the current platform are not reported.
+func registerProcessMetrics(meter otelmetric.Meter) error {
+	cpuTime, err := processconv.NewCPUTime(meter)
+	if err != nil {
+		return fmt.Errorf("failed to create process metrics: %w", err)
+	}
+	memory, err := meter.Int64ObservableUpDownCounter(
+		processconv.MemoryUsage{}.Name(),
+		otelmetric.WithDescription(processconv.MemoryUsage{}.Description()),
+		otelmetric.WithUnit(processconv.MemoryUsage{}.Unit()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create process metrics: %w", err)
+	}
+	fds, err := meter.Int64ObservableUpDownCounter(
+		processconv.OpenFileDescriptorCount{}.Name(),
+		otelmetric.WithDescription(processconv.OpenFileDescriptorCount{}.Description()),
+		otelmetric.WithUnit(processconv.OpenFileDescriptorCount{}.Unit()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create process metrics: %w", err)
+	}
+	uptime, err := meter.Float64ObservableGauge(
+		processconv.Uptime{}.Name(),
+		otelmetric.WithDescription(processconv.Uptime{}.Description()),
+		otelmetric.WithUnit(processconv.Uptime{}.Unit()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create process metrics: %w", err)
+	}
+
+	userAttr := otelmetric.WithAttributes(cpuTime.AttrCPUMode(processconv.CPUModeUser))
+	systemAttr := otelmetric.WithAttributes(cpuTime.AttrCPUMode(processconv.CPUModeSystem))
+
+	_, err = meter.RegisterCallback(func(ctx context.Context, o otelmetric.Observer) error {
+		if user, system, ok := processCPUTime(); ok {
+			o.ObserveFloat64(cpuTime.Inst(), user.Seconds(), userAttr)
+			o.ObserveFloat64(cpuTime.Inst(), system.Seconds(), systemAttr)
+		}
+		if rss, ok := processMemoryUsage(); ok {
+			o.ObserveInt64(memory, rss)
+		}
+		if count, ok := processOpenFileDescriptors(); ok {
+			o.ObserveInt64(fds, count)
+		}
+		o.ObserveFloat64(uptime, time.Since(processStart).Seconds())
+		return nil
+	}, cpuTime.Inst(), memory, fds, uptime)
+	if err != nil {
+		return fmt.Errorf("failed to register process metrics callback: %w", err)
+	}
+	return nil
+}
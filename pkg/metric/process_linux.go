@@ -0,0 +1,34 @@
+//go:build linux
+
+package metric
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+func processMemoryUsage() (int64, bool) {
+	// size resident shared text lib data dt, in pages
+	content, err := os.ReadFile("/proc/self/statm")
+	if err != nil {
+		return 0, false
+	}
+	fields := strings.Fields(string(content))
+	if len(fields) < 2 {
+		return 0, false
+	}
+	pages, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return pages * int64(os.Getpagesize()), true
+}
+
+func processOpenFileDescriptors() (int64, bool) {
+	entries, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		return 0, false
+	}
+	return int64(len(entries)), true
+}
@@ -0,0 +1,11 @@
+//go:build !linux
+
+package metric
+
+func processMemoryUsage() (int64, bool) {
+	return 0, false
+}
+
+func processOpenFileDescriptors() (int64, bool) {
+	return 0, false
+}
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package metric
+
+import "time"
+
+func processCPUTime() (user time.Duration, system time.Duration, ok bool) {
+	return 0, 0, false
+}
@@ -0,0 +1,16 @@
+//go:build unix
+
+package metric
+
+import (
+	"syscall"
+	"time"
+)
+
+func processCPUTime() (user time.Duration, system time.Duration, ok bool) {
+	var usage syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &usage); err != nil {
+		return 0, 0, false
+	}
+	return time.Duration(usage.Utime.Nano()), time.Duration(usage.Stime.Nano()), true
+}
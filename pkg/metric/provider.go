@@ -0,0 +1,144 @@
+package metric
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel"
+	otelmetric "go.opentelemetry.io/otel/metric"
+)
+
+type (
+	// OtelMeterProvider creates an OtelMeter per instrumentation scope, all
+	// sharing the options of the provider.
+	OtelMeterProvider struct {
+		provider otelmetric.MeterProvider
+		opts     OtelMeterOpts
+		// shutdown replaces the shutdown of provider, e.g. to also stop the
+		// metrics server.
+		shutdown func(ctx context.Context) error
+		meters   sync.Map
+		// declarations holds the first InstrumentInfo declared per name by
+		// the meters, whatever their scope.
+		declarations sync.Map
+	}
+
+	NoOpMeterProvider struct{}
+
+	providerHolder struct {
+		provider MeterProvider
+	}
+)
+
+var (
+	_ MeterProvider      = (*OtelMeterProvider)(nil)
+	_ InstrumentRegistry = (*OtelMeterProvider)(nil)
+	_ MeterProvider      = (*NoOpMeterProvider)(nil)
+
+	globalProvider atomic.Pointer[providerHolder]
+	providerOnce   sync.Once
+)
+
+func init() {
+	// The global OTel provider forwards to the one installed by
+	// SetupOTelMetrics, so meters created before it are not lost.
+	globalProvider.Store(&providerHolder{provider: NewOtelMeterProvider(otel.GetMeterProvider())})
+}
+
+func NewOtelMeterProvider(provider otelmetric.MeterProvider) *OtelMeterProvider {
+	return &OtelMeterProvider{provider: provider}
+}
+
+func NewOtelMeterProviderWithOpts(provider otelmetric.MeterProvider, opts OtelMeterOpts) *OtelMeterProvider {
+	return &OtelMeterProvider{provider: provider, opts: opts}
+}
+
+// Meter returns the meter of the instrumentation scope name, the same one on
+// every call.
+func (p *OtelMeterProvider) Meter(name string) Meter {
+	if meter, ok := p.meters.Load(name); ok {
+		return meter.(*OtelMeter)
+	}
+	created := NewOtelMeterWithOpts(p.provider.Meter(name), p.opts)
+	created.scope = name
+	created.declarations = &p.declarations
+	meter, _ := p.meters.LoadOrStore(name, created)
+	return meter.(*OtelMeter)
+}
+
+// Instruments returns the instruments registered in the meters of every
+// scope, sorted by name.
+func (p *OtelMeterProvider) Instruments() []InstrumentInfo {
+	var infos []InstrumentInfo
+	p.meters.Range(func(_, meter any) bool {
+		infos = append(infos, meter.(*OtelMeter).Instruments()...)
+		return true
+	})
+	sortInstruments(infos)
+	return infos
+}
+
+// holds reports whether meter is one of the meters of p.
+func (p *OtelMeterProvider) holds(meter Meter) bool {
+	held := false
+	p.meters.Range(func(_, value any) bool {
+		held = value == meter
+		return !held
+	})
+	return held
+}
+
+// Shutdown flushes and stops the underlying provider, when it supports it as
+// the SDK one does.
+func (p *OtelMeterProvider) Shutdown(ctx context.Context) error {
+	if p.shutdown != nil {
+		return p.shutdown(ctx)
+	}
+	if provider, ok := p.provider.(interface{ Shutdown(context.Context) error }); ok {
+		return provider.Shutdown(ctx)
+	}
+	return nil
+}
+
+func NewNoOpMeterProvider() *NoOpMeterProvider {
+	return &NoOpMeterProvider{}
+}
+
+func (p *NoOpMeterProvider) Meter(name string) Meter {
+	return NewNoOpMeter()
+}
+
+func (p *NoOpMeterProvider) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+func GetMeterProvider() MeterProvider {
+	return globalProvider.Load().provider
+}
+
+// SetMeterProvider sets the global meter provider. Only the first call has
+// effect; use ReplaceMeterProvider to override it afterwards.
+func SetMeterProvider(p MeterProvider) {
+	providerOnce.Do(func() {
+		globalProvider.Store(&providerHolder{provider: p})
+	})
+}
+
+// ReplaceMeterProvider replaces the global meter provider, regardless of
+// previous SetMeterProvider calls, and returns a function that restores the
+// previous one.
+func ReplaceMeterProvider(p MeterProvider) (restore func()) {
+	previous := globalProvider.Swap(&providerHolder{provider: p})
+	return func() {
+		globalProvider.Store(previous)
+	}
+}
+
+// NewMeter returns the meter of the instrumentation scope, such as the import
+// path of a library, from the global meter provider. Meters created before
+// SetupOTelMetrics forward to the provider it installs, without its
+// cardinality limits.
+func NewMeter(scope string) Meter {
+	return GetMeterProvider().Meter(scope)
+}
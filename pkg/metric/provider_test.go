@@ -0,0 +1,99 @@
+package metric
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestOtelMeterProvider_MeterPerScope(t *testing.T) {
+	ctx := context.Background()
+	reader := sdkmetric.NewManualReader()
+	provider := NewOtelMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	t.Cleanup(func() { _ = provider.Shutdown(ctx) })
+
+	orders := provider.Meter("orders")
+	if provider.Meter("orders") != orders {
+		t.Error("expected the same meter for the same scope")
+	}
+	if provider.Meter("payments") == orders {
+		t.Error("expected a meter per scope")
+	}
+
+	_ = orders.AddCounter(ctx, "orders.created", "Orders", "1", 1)
+	_ = provider.Meter("payments").AddCounter(ctx, "payments.created", "Payments", "1", 1)
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("failed to collect metrics: %v", err)
+	}
+	scopes := make(map[string]string)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			scopes[m.Name] = sm.Scope.Name
+		}
+	}
+	if scopes["orders.created"] != "orders" || scopes["payments.created"] != "payments" {
+		t.Errorf("unexpected scopes: %v", scopes)
+	}
+}
+
+func TestOtelMeterProvider_SharesOpts(t *testing.T) {
+	ctx := context.Background()
+	sdkProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewManualReader()))
+	provider := NewOtelMeterProviderWithOpts(sdkProvider, OtelMeterOpts{CardinalityLimit: 2})
+	t.Cleanup(func() { _ = provider.Shutdown(ctx) })
+
+	meter := provider.Meter("orders").(*OtelMeter)
+	if meter.cardinalityLimit != 2 {
+		t.Errorf("expected the cardinality limit of the provider, got %d", meter.cardinalityLimit)
+	}
+}
+
+func TestOtelMeterProvider_Shutdown(t *testing.T) {
+	ctx := context.Background()
+	provider := NewOtelMeterProvider(sdkmetric.NewMeterProvider())
+
+	if err := provider.Shutdown(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := provider.Shutdown(ctx); err == nil {
+		t.Error("expected the SDK provider to be shut down")
+	}
+
+	// providers without Shutdown are ignored
+	if err := NewOtelMeterProvider(noop.NewMeterProvider()).Shutdown(ctx); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestNoOpMeterProvider(t *testing.T) {
+	provider := NewNoOpMeterProvider()
+	if _, ok := provider.Meter("orders").(*NoOpMeter); !ok {
+		t.Errorf("expected a no-op meter, got %T", provider.Meter("orders"))
+	}
+	if err := provider.Shutdown(context.Background()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestReplaceMeterProvider(t *testing.T) {
+	original := GetMeterProvider()
+
+	replacement := NewNoOpMeterProvider()
+	restore := ReplaceMeterProvider(replacement)
+	if GetMeterProvider() != replacement {
+		t.Error("expected replaced meter provider")
+	}
+	if _, ok := NewMeter("orders").(*NoOpMeter); !ok {
+		t.Errorf("expected NewMeter to use the replaced provider, got %T", NewMeter("orders"))
+	}
+
+	restore()
+	if GetMeterProvider() != original {
+		t.Error("expected original meter provider to be restored")
+	}
+}
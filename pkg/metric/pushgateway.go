@@ -0,0 +1,155 @@
+package metric
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/bruno303/go-toolkit/pkg/log"
+	"github.com/prometheus/client_golang/prometheus/push"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// defaultPushInterval matches the default interval of the OTel periodic
+// reader used by the other push exporters.
+const defaultPushInterval = 60 * time.Second
+
+type (
+	// PushgatewayConfig configures ExporterPushgateway.
+	PushgatewayConfig struct {
+		// URL of the Pushgateway, such as http://pushgateway:9091.
+		URL string
+		// Job is the job label of the pushed metrics. Defaults to the
+		// application name.
+		Job string
+		// Grouping adds labels, such as the instance, to the grouping key, so
+		// jobs sharing a name do not replace the metrics of each other.
+		Grouping map[string]string
+		// Client sends the requests. Defaults to http.DefaultClient.
+		Client *http.Client
+	}
+
+	// pushgatewayReader is a Prometheus reader whose registry is pushed
+	// every interval once started, and when the reader shuts down.
+	pushgatewayReader struct {
+		sdkmetric.Reader
+		pusher   *push.Pusher
+		interval time.Duration
+		log      log.Logger
+		mu       sync.Mutex
+		started  bool
+		stopped  bool
+		stop     chan struct{}
+		done     chan struct{}
+	}
+)
+
+func newPushgatewayReader(cfg Config, producers []sdkmetric.Producer) (sdkmetric.Reader, error) {
+	pgCfg := cfg.Pushgateway
+	if pgCfg.URL == "" {
+		return nil, errors.New("pushgateway URL is required")
+	}
+	job := pgCfg.Job
+	if job == "" {
+		job = cfg.ApplicationName
+	}
+	if job == "" {
+		return nil, errors.New("pushgateway job is required")
+	}
+
+	reader, registry, err := newPrometheusRegistry(cfg, producers)
+	if err != nil {
+		return nil, err
+	}
+	pusher := push.New(pgCfg.URL, job).Gatherer(registry)
+	for name, value := range pgCfg.Grouping {
+		pusher = pusher.Grouping(name, value)
+	}
+	if pgCfg.Client != nil {
+		pusher = pusher.Client(pgCfg.Client)
+	}
+
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = defaultPushInterval
+	}
+	logger := cfg.Log
+	if logger == nil {
+		logger = log.Log()
+	}
+
+	return &pushgatewayReader{
+		Reader:   reader,
+		pusher:   pusher,
+		interval: interval,
+		log:      logger,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}, nil
+}
+
+// startPushgatewayReaders starts the pushes of the Pushgateway readers among
+// readers, once they are registered with a provider.
+func startPushgatewayReaders(readers []sdkmetric.Reader) {
+	for _, reader := range readers {
+		if r, ok := reader.(*pushgatewayReader); ok {
+			r.start()
+		}
+	}
+}
+
+func (r *pushgatewayReader) start() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.started || r.stopped {
+		return
+	}
+	r.started = true
+	go r.run(r.interval)
+}
+
+func (r *pushgatewayReader) run(interval time.Duration) {
+	defer close(r.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			if err := r.push(ctx); err != nil {
+				r.log.Error(ctx, "failed to push metrics", err)
+			}
+			cancel()
+		case <-r.stop:
+			return
+		}
+	}
+}
+
+func (r *pushgatewayReader) push(ctx context.Context) error {
+	if err := r.pusher.PushContext(ctx); err != nil {
+		return fmt.Errorf("failed to push metrics to the pushgateway: %w", err)
+	}
+	return nil
+}
+
+// Shutdown stops the periodic pushes and pushes the last values before
+// shutting down the Prometheus reader. A reader that was never started
+// pushes nothing, as it has no metrics.
+func (r *pushgatewayReader) Shutdown(ctx context.Context) error {
+	r.mu.Lock()
+	started, stopped := r.started, r.stopped
+	r.stopped = true
+	r.mu.Unlock()
+
+	var err error
+	if started && !stopped {
+		close(r.stop)
+		<-r.done
+		err = r.push(ctx)
+	}
+	return errors.Join(err, r.Reader.Shutdown(ctx))
+}
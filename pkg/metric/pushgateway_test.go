@@ -0,0 +1,164 @@
+package metric
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// pushgatewayStub records the pushes it receives.
+type pushgatewayStub struct {
+	mu     sync.Mutex
+	pushes []string
+	bodies []string
+}
+
+func newPushgatewayStub(t *testing.T) (*pushgatewayStub, *httptest.Server) {
+	t.Helper()
+	stub := &pushgatewayStub{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		stub.mu.Lock()
+		stub.pushes = append(stub.pushes, r.Method+" "+r.URL.Path)
+		stub.bodies = append(stub.bodies, string(body))
+		stub.mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return stub, server
+}
+
+func (s *pushgatewayStub) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.pushes)
+}
+
+func TestNewMeterProvider_PushgatewayExporter(t *testing.T) {
+	ctx := context.Background()
+	stub, server := newPushgatewayStub(t)
+
+	cfg := Config{
+		ApplicationName: "batch-job",
+		Exporters:       []ExporterType{ExporterPushgateway},
+		Pushgateway: PushgatewayConfig{
+			URL:      server.URL,
+			Grouping: map[string]string{"instance": "worker-1"},
+		},
+		Interval:   time.Hour,
+		DisableEnv: true,
+	}
+	provider, handler, err := newMeterProvider(ctx, &cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if handler != nil {
+		t.Error("expected no handler without the prometheus exporter")
+	}
+
+	_ = NewOtelMeter(provider.Meter("test")).AddCounter(ctx, "jobs.processed", "Jobs", "1", 3)
+	if err := provider.Shutdown(ctx); err != nil {
+		t.Fatalf("failed to shutdown: %v", err)
+	}
+
+	stub.mu.Lock()
+	defer stub.mu.Unlock()
+	if len(stub.pushes) != 1 {
+		t.Fatalf("expected a single push on shutdown, got %v", stub.pushes)
+	}
+	if stub.pushes[0] != "PUT /metrics/job/batch-job/instance/worker-1" {
+		t.Errorf("unexpected push %s", stub.pushes[0])
+	}
+	if !strings.Contains(stub.bodies[0], "jobs_processed") {
+		t.Error("expected the pushed metrics to contain the counter")
+	}
+}
+
+func TestNewMeterProvider_PushgatewayPushesPeriodically(t *testing.T) {
+	ctx := context.Background()
+	stub, server := newPushgatewayStub(t)
+
+	cfg := Config{
+		Exporters:   []ExporterType{ExporterPushgateway},
+		Pushgateway: PushgatewayConfig{URL: server.URL, Job: "cron"},
+		Interval:    10 * time.Millisecond,
+		DisableEnv:  true,
+	}
+	provider, _, err := newMeterProvider(ctx, &cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() { _ = provider.Shutdown(ctx) })
+
+	deadline := time.Now().Add(5 * time.Second)
+	for stub.count() < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if stub.count() < 2 {
+		t.Errorf("expected periodic pushes, got %d", stub.count())
+	}
+}
+
+func TestNewMeterProvider_PushgatewayErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  PushgatewayConfig
+	}{
+		{"missing URL", PushgatewayConfig{Job: "cron"}},
+		{"missing job", PushgatewayConfig{URL: "http://localhost:9091"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{Exporters: []ExporterType{ExporterPushgateway}, Pushgateway: tt.cfg, DisableEnv: true}
+			if _, _, err := newMeterProvider(context.Background(), &cfg); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestNewMeterProvider_PushgatewayStopsOnLaterError(t *testing.T) {
+	stub, server := newPushgatewayStub(t)
+
+	cfg := Config{
+		Exporters:   []ExporterType{ExporterPushgateway, "zipkin"},
+		Pushgateway: PushgatewayConfig{URL: server.URL, Job: "cron"},
+		Interval:    time.Millisecond,
+		DisableEnv:  true,
+	}
+	if _, _, err := newMeterProvider(context.Background(), &cfg); err == nil {
+		t.Fatal("expected error")
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if stub.count() != 0 {
+		t.Errorf("expected no push after a failed setup, got %d", stub.count())
+	}
+}
+
+func TestNewMeterProvider_PushgatewayShutdownReturnsPushError(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := Config{
+		Exporters:   []ExporterType{ExporterPushgateway},
+		Pushgateway: PushgatewayConfig{URL: server.URL, Job: "cron"},
+		DisableEnv:  true,
+	}
+	provider, _, err := newMeterProvider(ctx, &cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := provider.Shutdown(ctx); err == nil {
+		t.Error("expected the last push error")
+	}
+}
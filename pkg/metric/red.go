@@ -0,0 +1,221 @@
+package metric
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+)
+
+type (
+	// REDOpts configures the metrics of NewREDWithOpts.
+	REDOpts struct {
+		// Meter records the metrics. Defaults to the meter of the metric package
+		// scope from NewMeter, which also records the measurements of a RED
+		// created before SetupOTelMetrics.
+		Meter Meter
+		// Attributes are recorded with every measurement.
+		Attributes []Attribute
+		// Buckets of the duration histogram. Defaults to DurationBuckets.
+		Buckets []float64
+		// SLO, when set, also reports the burn rate of the error budget.
+		SLO *SLOOpts
+	}
+
+	// RED records the rate, errors and duration of an operation as:
+	//   - <operation>.requests, a counter of the calls;
+	//   - <operation>.errors, a counter of the failed calls;
+	//   - <operation>.duration, a histogram of the durations in seconds, with
+	//     the outcome attribute set to success or error.
+	RED struct {
+		operation string
+		requests  Int64Counter
+		errors    Int64Counter
+		duration  Histogram
+		attrs     []Attribute
+		// The sets of attrs, alone and with each outcome, recorded when no
+		// attributes are given to Record.
+		set        AttributeSet
+		successSet AttributeSet
+		errorSet   AttributeSet
+		slo        *slo
+		unregister []func() error
+		// key and opts identify the RED in reds.
+		key  redKey
+		opts REDOpts
+	}
+
+	redKey struct {
+		meter     Meter
+		operation string
+	}
+)
+
+var (
+	// reds holds the REDs by meter and operation, so that the SLO gauges of
+	// an operation are observed once.
+	reds   = make(map[redKey]*RED)
+	redsMu sync.Mutex
+)
+
+// NewRED returns the RED metrics of operation, such as "orders.checkout". It
+// can be declared in a package variable, before the metrics are set up.
+func NewRED(operation string) (*RED, error) {
+	return NewREDWithOpts(operation, REDOpts{})
+}
+
+// NewREDWithOpts returns the RED metrics of operation. Creating it again with
+// the same meter returns the existing RED, until it is closed, so its SLO
+// gauges are observed once; options differing from the existing ones return
+// an error matching ErrInstrumentConflict.
+func NewREDWithOpts(operation string, opts REDOpts) (*RED, error) {
+	if operation == "" {
+		return nil, errors.New("RED operation name is required")
+	}
+	if opts.Meter == nil {
+		opts.Meter = NewMeter(instrumentationName)
+	}
+	if len(opts.Buckets) == 0 {
+		opts.Buckets = DurationBuckets
+	}
+
+	// Meters of non comparable types cannot be keys, their REDs are not
+	// shared.
+	if !reflect.TypeOf(opts.Meter).Comparable() {
+		return newRED(operation, opts)
+	}
+	key := redKey{meter: opts.Meter, operation: operation}
+	redsMu.Lock()
+	defer redsMu.Unlock()
+	if existing, ok := reds[key]; ok {
+		if !reflect.DeepEqual(existing.opts, opts) {
+			return nil, fmt.Errorf("RED %s is already declared with other options: %w", operation, ErrInstrumentConflict)
+		}
+		return existing, nil
+	}
+	r, err := newRED(operation, opts)
+	if err != nil {
+		return nil, err
+	}
+	r.key = key
+	reds[key] = r
+	return r, nil
+}
+
+func newRED(operation string, opts REDOpts) (*RED, error) {
+	meter := opts.Meter
+	buckets := opts.Buckets
+	r := &RED{
+		operation:  operation,
+		opts:       opts,
+		attrs:      opts.Attributes,
+		set:        NewAttributeSet(opts.Attributes...),
+		successSet: NewAttributeSet(withOutcome(opts.Attributes, OutcomeSuccess)...),
+		errorSet:   NewAttributeSet(withOutcome(opts.Attributes, OutcomeError)...),
+	}
+
+	var err error
+	if r.requests, err = meter.Int64Counter(operation+".requests", InstrumentOpts{
+		Description: fmt.Sprintf("Requests of %s", operation),
+		Unit:        "{request}",
+	}); err != nil {
+		return nil, fmt.Errorf("failed to create the RED metrics of %s: %w", operation, err)
+	}
+	if r.errors, err = meter.Int64Counter(operation+".errors", InstrumentOpts{
+		Description: fmt.Sprintf("Failed requests of %s", operation),
+		Unit:        "{error}",
+	}); err != nil {
+		return nil, fmt.Errorf("failed to create the RED metrics of %s: %w", operation, err)
+	}
+	if r.duration, err = meter.Histogram(operation+".duration", InstrumentOpts{
+		Description: fmt.Sprintf("Duration of %s", operation),
+		Unit:        "s",
+		Buckets:     buckets,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to create the RED metrics of %s: %w", operation, err)
+	}
+
+	if opts.SLO != nil {
+		r.slo, err = newSLO(*opts.SLO)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SLO of %s: %w", operation, err)
+		}
+		unregister, err := meter.ObserveGauge(operation+".slo.burn_rate",
+			fmt.Sprintf("Burn rate of the error budget of %s", operation), "1", r.observeBurnRates)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the SLO metrics of %s: %w", operation, err)
+		}
+		r.unregister = append(r.unregister, unregister)
+	}
+	return r, nil
+}
+
+// Record records a call of the operation which took elapsed and failed when
+// err is not nil. attrs are recorded besides the attributes of the options.
+func (r *RED) Record(ctx context.Context, elapsed time.Duration, err error, attrs ...Attribute) {
+	failed := err != nil
+	if r.slo != nil {
+		r.slo.add(time.Now(), failed)
+	}
+
+	if len(attrs) == 0 {
+		r.requests.AddSet(ctx, 1, r.set)
+		outcomeSet := r.successSet
+		if failed {
+			r.errors.AddSet(ctx, 1, r.set)
+			outcomeSet = r.errorSet
+		}
+		r.duration.RecordSet(ctx, elapsed.Seconds(), outcomeSet)
+		return
+	}
+
+	all := slices.Concat(r.attrs, attrs)
+	r.requests.Add(ctx, 1, all...)
+	outcome := OutcomeSuccess
+	if failed {
+		r.errors.Add(ctx, 1, all...)
+		outcome = OutcomeError
+	}
+	r.duration.Record(ctx, elapsed.Seconds(), withOutcome(all, outcome)...)
+}
+
+// Close unregisters the SLO gauges. The counters and histogram remain in the
+// meter, and NewREDWithOpts creates a new RED for the operation.
+func (r *RED) Close() error {
+	redsMu.Lock()
+	if reds[r.key] == r {
+		delete(reds, r.key)
+	}
+	redsMu.Unlock()
+
+	var errs []error
+	for _, unregister := range r.unregister {
+		errs = append(errs, unregister())
+	}
+	r.unregister = nil
+	return errors.Join(errs...)
+}
+
+func (r *RED) observeBurnRates(ctx context.Context, o Observer) error {
+	now := time.Now()
+	for _, w := range r.slo.windows {
+		attrs := slices.Concat(r.attrs, []Attribute{String(SLOWindowAttributeKey, formatWindow(w.window))})
+		o.Observe(w.burnRate(now, r.slo.target), attrs...)
+	}
+	return nil
+}
+
+// MeasureRED calls fn and records it in red, failed when fn returns an error.
+func MeasureRED[T any](ctx context.Context, red *RED, fn func(ctx context.Context) (T, error)) (T, error) {
+	start := time.Now()
+	result, err := fn(ctx)
+	red.Record(ctx, time.Since(start), err)
+	return result, err
+}
+
+func withOutcome(attrs []Attribute, outcome string) []Attribute {
+	return slices.Concat(attrs, []Attribute{String(OutcomeAttributeKey, outcome)})
+}
@@ -0,0 +1,169 @@
+package metric_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bruno303/go-toolkit/pkg/metric"
+	"github.com/bruno303/go-toolkit/pkg/metric/metrictest"
+)
+
+func TestRED_Record(t *testing.T) {
+	rec := metrictest.NewRecorder(t)
+	ctx := context.Background()
+	service := metric.String("service", "orders")
+
+	red, err := metric.NewREDWithOpts("orders.checkout", metric.REDOpts{Attributes: []metric.Attribute{service}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	red.Record(ctx, 100*time.Millisecond, nil)
+	red.Record(ctx, 300*time.Millisecond, errors.New("failure"))
+	red.Record(ctx, time.Second, nil, metric.String("region", "eu"))
+
+	base := []metric.Attribute{service}
+	withRegion := []metric.Attribute{service, metric.String("region", "eu")}
+	metrictest.AssertCounter(t, rec, "orders.checkout.requests", base, 2)
+	metrictest.AssertCounter(t, rec, "orders.checkout.requests", withRegion, 1)
+	metrictest.AssertCounter(t, rec, "orders.checkout.errors", base, 1)
+	metrictest.AssertNotRecorded(t, rec, "orders.checkout.slo.burn_rate")
+
+	success := []metric.Attribute{service, metric.String(metric.OutcomeAttributeKey, metric.OutcomeSuccess)}
+	failure := []metric.Attribute{service, metric.String(metric.OutcomeAttributeKey, metric.OutcomeError)}
+	metrictest.AssertHistogramCount(t, rec, "orders.checkout.duration", success, 1)
+	metrictest.AssertHistogramSum(t, rec, "orders.checkout.duration", failure, 0.3)
+	metrictest.AssertHistogramCount(t, rec, "orders.checkout.duration",
+		append(withRegion, metric.String(metric.OutcomeAttributeKey, metric.OutcomeSuccess)), 1)
+}
+
+func TestNewRED_UsesMeterProvider(t *testing.T) {
+	rec := metrictest.NewRecorder(t)
+	// the global meter is set by the setup, after package variables
+	t.Cleanup(metric.ReplaceMeter(metric.NewNoOpMeter()))
+
+	red, err := metric.NewRED("orders.checkout")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	red.Record(context.Background(), time.Millisecond, nil)
+
+	metrictest.AssertCounter(t, rec, "orders.checkout.requests", nil, 1)
+}
+
+func TestMeasureRED(t *testing.T) {
+	rec := metrictest.NewRecorder(t)
+	ctx := context.Background()
+	expectedErr := errors.New("failure")
+
+	red, err := metric.NewRED("payments.charge")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := metric.MeasureRED(ctx, red, func(ctx context.Context) (int, error) {
+		return 42, nil
+	})
+	if err != nil || got != 42 {
+		t.Fatalf("expected 42, got %d, %v", got, err)
+	}
+	if _, err := metric.MeasureRED(ctx, red, func(ctx context.Context) (int, error) {
+		return 0, expectedErr
+	}); !errors.Is(err, expectedErr) {
+		t.Fatalf("expected error %v, got %v", expectedErr, err)
+	}
+
+	metrictest.AssertCounter(t, rec, "payments.charge.requests", nil, 2)
+	metrictest.AssertCounter(t, rec, "payments.charge.errors", nil, 1)
+}
+
+func TestRED_SLOBurnRate(t *testing.T) {
+	rec := metrictest.NewRecorder(t)
+	ctx := context.Background()
+
+	red, err := metric.NewREDWithOpts("orders.checkout", metric.REDOpts{
+		SLO: &metric.SLOOpts{Target: 0.9, Windows: []time.Duration{5 * time.Minute, time.Hour}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range 10 {
+		var err error
+		if i < 2 {
+			err = errors.New("failure")
+		}
+		red.Record(ctx, time.Millisecond, err)
+	}
+
+	// 20% of errors against a 10% budget
+	for _, window := range []string{"5m", "1h"} {
+		metrictest.AssertGauge(t, rec, "orders.checkout.slo.burn_rate",
+			[]metric.Attribute{metric.String(metric.SLOWindowAttributeKey, window)}, 2)
+	}
+
+	if err := red.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestNewREDWithOpts_SameOperation(t *testing.T) {
+	rec := metrictest.NewRecorder(t)
+	opts := metric.REDOpts{Meter: rec.Meter(), SLO: &metric.SLOOpts{Target: 0.9, Windows: []time.Duration{time.Hour}}}
+
+	first, err := metric.NewREDWithOpts("orders.checkout", opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := metric.NewREDWithOpts("orders.checkout", opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if second != first {
+		t.Error("expected the existing RED to be returned")
+	}
+	second.Record(context.Background(), time.Millisecond, errors.New("failure"))
+
+	metrictest.AssertGauge(t, rec, "orders.checkout.slo.burn_rate",
+		[]metric.Attribute{metric.String(metric.SLOWindowAttributeKey, "1h")}, 10)
+
+	_, err = metric.NewREDWithOpts("orders.checkout", metric.REDOpts{Meter: rec.Meter()})
+	if !errors.Is(err, metric.ErrInstrumentConflict) {
+		t.Errorf("expected a conflict for other options, got %v", err)
+	}
+
+	if err := first.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	third, err := metric.NewREDWithOpts("orders.checkout", opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer third.Close()
+	if third == first {
+		t.Error("expected a new RED after Close")
+	}
+}
+
+func TestNewREDWithOpts_Errors(t *testing.T) {
+	rec := metrictest.NewRecorder(t)
+	_ = rec.Meter().AddGauge(context.Background(), "conflict.requests", "", "1", 1)
+
+	tests := []struct {
+		name      string
+		operation string
+		opts      metric.REDOpts
+	}{
+		{"missing operation", "", metric.REDOpts{}},
+		{"target out of range", "orders", metric.REDOpts{SLO: &metric.SLOOpts{Target: 1}}},
+		{"negative window", "orders", metric.REDOpts{SLO: &metric.SLOOpts{Target: 0.99, Windows: []time.Duration{-time.Minute}}}},
+		{"conflicting instrument", "conflict", metric.REDOpts{Meter: rec.Meter()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := metric.NewREDWithOpts(tt.operation, tt.opts); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package metric
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"runtime/metrics"
+	"sync"
+	"time"
+
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/semconv/v1.37.0/goconv"
+)
+
+const instrumentationName = "github.com/bruno303/go-toolkit/pkg/metric"
+
+const (
+	rtGoroutines     = "/sched/goroutines:goroutines"
+	rtMemoryTotal    = "/memory/classes/total:bytes"
+	rtMemoryReleased = "/memory/classes/heap/released:bytes"
+	rtMemoryStacks   = "/memory/classes/heap/stacks:bytes"
+	rtMemoryOSStacks = "/memory/classes/os-stacks:bytes"
+	rtMemoryLimit    = "/gc/gomemlimit:bytes"
+	rtAllocBytes     = "/gc/heap/allocs:bytes"
+	rtAllocObjects   = "/gc/heap/allocs:objects"
+	rtGCGoal         = "/gc/heap/goal:bytes"
+	rtGOMAXPROCS     = "/sched/gomaxprocs:threads"
+	rtGOGC           = "/gc/gogc:percent"
+	rtSchedLatencies = "/sched/latencies:seconds"
+	rtGCPauses       = "/sched/pauses/total/gc:seconds"
+
+	gcPauseDurationName = "go.gc.pause.duration"
+)
+
+// runtimeHistogramBounds are the bucket boundaries, in seconds, the runtime
+// histograms are aggregated into.
+var runtimeHistogramBounds = []float64{1e-6, 5e-6, 1e-5, 5e-5, 1e-4, 5e-4, 1e-3, 5e-3, 1e-2, 5e-2, 0.1, 0.5, 1}
+
+type (
+	runtimeReader struct {
+		mu      sync.Mutex
+		samples []metrics.Sample
+		index   map[string]int
+	}
+
+	// runtimeHistogramProducer reports the scheduler latency and GC pause
+	// histograms of the runtime, which cannot be recorded with observable
+	// instruments.
+	runtimeHistogramProducer struct {
+		reader *runtimeReader
+		start  time.Time
+	}
+)
+
+var _ sdkmetric.Producer = (*runtimeHistogramProducer)(nil)
+
+func newRuntimeReader(names ...string) *runtimeReader {
+	r := &runtimeReader{
+		samples: make([]metrics.Sample, len(names)),
+		index:   make(map[string]int, len(names)),
+	}
+	for i, name := range names {
+		r.samples[i].Name = name
+		r.index[name] = i
+	}
+	return r
+}
+
+// read refreshes the samples and calls fn while holding the lock, as the
+// callbacks of different readers may run concurrently.
+func (r *runtimeReader) read(fn func(value func(name string) metrics.Value)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	metrics.Read(r.samples)
+	fn(func(name string) metrics.Value {
+		return r.samples[r.index[name]].Value
+	})
+}
+
+// registerRuntimeMetrics registers observable instruments for the Go runtime
+// metrics, following the go.* semantic conventions.
+func registerRuntimeMetrics(meter otelmetric.Meter) error {
+	goroutines, err := goconv.NewGoroutineCount(meter)
+	if err != nil {
+		return fmt.Errorf("failed to create runtime metrics: %w", err)
+	}
+	memoryUsed, err := goconv.NewMemoryUsed(meter)
+	if err != nil {
+		return fmt.Errorf("failed to create runtime metrics: %w", err)
+	}
+	memoryLimit, err := goconv.NewMemoryLimit(meter)
+	if err != nil {
+		return fmt.Errorf("failed to create runtime metrics: %w", err)
+	}
+	allocated, err := goconv.NewMemoryAllocated(meter)
+	if err != nil {
+		return fmt.Errorf("failed to create runtime metrics: %w", err)
+	}
+	allocations, err := goconv.NewMemoryAllocations(meter)
+	if err != nil {
+		return fmt.Errorf("failed to create runtime metrics: %w", err)
+	}
+	gcGoal, err := goconv.NewMemoryGCGoal(meter)
+	if err != nil {
+		return fmt.Errorf("failed to create runtime metrics: %w", err)
+	}
+	processorLimit, err := goconv.NewProcessorLimit(meter)
+	if err != nil {
+		return fmt.Errorf("failed to create runtime metrics: %w", err)
+	}
+	gogc, err := goconv.NewConfigGogc(meter)
+	if err != nil {
+		return fmt.Errorf("failed to create runtime metrics: %w", err)
+	}
+
+	reader := newRuntimeReader(
+		rtGoroutines, rtMemoryTotal, rtMemoryReleased, rtMemoryStacks, rtMemoryOSStacks,
+		rtMemoryLimit, rtAllocBytes, rtAllocObjects, rtGCGoal, rtGOMAXPROCS, rtGOGC,
+	)
+	stackAttr := otelmetric.WithAttributes(memoryUsed.AttrMemoryType(goconv.MemoryTypeStack))
+	otherAttr := otelmetric.WithAttributes(memoryUsed.AttrMemoryType(goconv.MemoryTypeOther))
+
+	_, err = meter.RegisterCallback(func(ctx context.Context, o otelmetric.Observer) error {
+		reader.read(func(value func(name string) metrics.Value) {
+			stack := uint64Value(value(rtMemoryStacks)) + uint64Value(value(rtMemoryOSStacks))
+			used := uint64Value(value(rtMemoryTotal)) - uint64Value(value(rtMemoryReleased))
+
+			o.ObserveInt64(goroutines.Inst(), uint64Value(value(rtGoroutines)))
+			o.ObserveInt64(memoryUsed.Inst(), stack, stackAttr)
+			o.ObserveInt64(memoryUsed.Inst(), used-stack, otherAttr)
+			o.ObserveInt64(memoryLimit.Inst(), uint64Value(value(rtMemoryLimit)))
+			o.ObserveInt64(allocated.Inst(), uint64Value(value(rtAllocBytes)))
+			o.ObserveInt64(allocations.Inst(), uint64Value(value(rtAllocObjects)))
+			o.ObserveInt64(gcGoal.Inst(), uint64Value(value(rtGCGoal)))
+			o.ObserveInt64(processorLimit.Inst(), uint64Value(value(rtGOMAXPROCS)))
+			o.ObserveInt64(gogc.Inst(), uint64Value(value(rtGOGC)))
+		})
+		return nil
+	},
+		goroutines.Inst(), memoryUsed.Inst(), memoryLimit.Inst(), allocated.Inst(),
+		allocations.Inst(), gcGoal.Inst(), processorLimit.Inst(), gogc.Inst(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register runtime metrics callback: %w", err)
+	}
+	return nil
+}
+
+func newRuntimeHistogramProducer() *runtimeHistogramProducer {
+	return &runtimeHistogramProducer{
+		reader: newRuntimeReader(rtSchedLatencies, rtGCPauses),
+		start:  time.Now(),
+	}
+}
+
+func (p *runtimeHistogramProducer) Produce(context.Context) ([]metricdata.ScopeMetrics, error) {
+	var schedule, pauses metricdata.HistogramDataPoint[float64]
+	now := time.Now()
+	p.reader.read(func(value func(name string) metrics.Value) {
+		schedule = toHistogramDataPoint(value(rtSchedLatencies), p.start, now)
+		pauses = toHistogramDataPoint(value(rtGCPauses), p.start, now)
+	})
+
+	scheduleDuration := goconv.ScheduleDuration{}
+	return []metricdata.ScopeMetrics{{
+		Scope: instrumentation.Scope{Name: instrumentationName},
+		Metrics: []metricdata.Metrics{
+			{
+				Name:        scheduleDuration.Name(),
+				Description: scheduleDuration.Description(),
+				Unit:        scheduleDuration.Unit(),
+				Data: metricdata.Histogram[float64]{
+					Temporality: metricdata.CumulativeTemporality,
+					DataPoints:  []metricdata.HistogramDataPoint[float64]{schedule},
+				},
+			},
+			{
+				Name:        gcPauseDurationName,
+				Description: "The time the application was paused by the garbage collector.",
+				Unit:        "s",
+				Data: metricdata.Histogram[float64]{
+					Temporality: metricdata.CumulativeTemporality,
+					DataPoints:  []metricdata.HistogramDataPoint[float64]{pauses},
+				},
+			},
+		},
+	}}, nil
+}
+
+// toHistogramDataPoint aggregates a runtime histogram into the
+// runtimeHistogramBounds buckets.
+func toHistogramDataPoint(v metrics.Value, start time.Time, now time.Time) metricdata.HistogramDataPoint[float64] {
+	dp := metricdata.HistogramDataPoint[float64]{
+		StartTime:    start,
+		Time:         now,
+		Bounds:       runtimeHistogramBounds,
+		BucketCounts: make([]uint64, len(runtimeHistogramBounds)+1),
+	}
+	if v.Kind() == metrics.KindFloat64Histogram {
+		aggregateHistogram(&dp, v.Float64Histogram())
+	}
+	return dp
+}
+
+// aggregateHistogram adds the counts of h to the buckets of dp. The sum is
+// estimated from the middle of the runtime buckets.
+func aggregateHistogram(dp *metricdata.HistogramDataPoint[float64], h *metrics.Float64Histogram) {
+	for i, count := range h.Counts {
+		if count == 0 {
+			continue
+		}
+		lower, upper := h.Buckets[i], h.Buckets[i+1]
+
+		bucket := len(dp.Bounds)
+		for j, bound := range dp.Bounds {
+			if upper <= bound {
+				bucket = j
+				break
+			}
+		}
+		dp.BucketCounts[bucket] += count
+		dp.Count += count
+
+		switch {
+		case math.IsInf(upper, 1):
+			dp.Sum += lower * float64(count)
+		case math.IsInf(lower, -1):
+			dp.Sum += upper * float64(count)
+		default:
+			dp.Sum += (lower + upper) / 2 * float64(count)
+		}
+	}
+}
+
+func uint64Value(v metrics.Value) int64 {
+	if v.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	u := v.Uint64()
+	if u > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return int64(u)
+}
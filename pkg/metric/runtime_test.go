@@ -0,0 +1,93 @@
+package metric
+
+import (
+	"context"
+	"math"
+	"runtime"
+	"runtime/metrics"
+	"testing"
+	"time"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestRegisterSystemMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader(sdkmetric.WithProducer(newRuntimeHistogramProducer()))
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	if err := registerSystemMetrics(provider, Config{RuntimeMetrics: true, ProcessMetrics: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	runtime.GC()
+
+	got := collect(t, reader)
+	expected := []string{
+		"go.goroutine.count",
+		"go.memory.used",
+		"go.memory.limit",
+		"go.memory.allocated",
+		"go.memory.allocations",
+		"go.memory.gc.goal",
+		"go.processor.limit",
+		"go.config.gogc",
+		"go.schedule.duration",
+		gcPauseDurationName,
+		"process.uptime",
+	}
+	if runtime.GOOS == "linux" {
+		expected = append(expected, "process.cpu.time", "process.memory.usage", "process.open_file_descriptor.count")
+	}
+	for _, name := range expected {
+		if _, ok := got[name]; !ok {
+			t.Errorf("expected metric %s to be reported", name)
+		}
+	}
+
+	goroutines, ok := got["go.goroutine.count"].(metricdata.Sum[int64])
+	if !ok || len(goroutines.DataPoints) != 1 || goroutines.DataPoints[0].Value < 1 {
+		t.Errorf("expected a positive goroutine count, got %+v", got["go.goroutine.count"])
+	}
+	pauses, ok := got[gcPauseDurationName].(metricdata.Histogram[float64])
+	if !ok || len(pauses.DataPoints) != 1 || pauses.DataPoints[0].Count == 0 {
+		t.Errorf("expected GC pauses after runtime.GC, got %+v", got[gcPauseDurationName])
+	}
+}
+
+func TestRegisterSystemMetrics_Disabled(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	if err := registerSystemMetrics(provider, Config{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := collect(t, reader); len(got) != 0 {
+		t.Errorf("expected no metrics, got %v", got)
+	}
+}
+
+func TestAggregateHistogram(t *testing.T) {
+	h := &metrics.Float64Histogram{
+		Counts:  []uint64{1, 2, 0, 3},
+		Buckets: []float64{math.Inf(-1), 1e-6, 2e-6, 0.2, math.Inf(1)},
+	}
+	dp := toHistogramDataPoint(metrics.Value{}, time.Time{}, time.Time{})
+	if dp.Count != 0 {
+		t.Errorf("expected an empty data point for an unsupported value, got %d", dp.Count)
+	}
+	aggregateHistogram(&dp, h)
+
+	if dp.Count != 6 {
+		t.Errorf("expected count 6, got %d", dp.Count)
+	}
+	// (-Inf, 1e-6] -> 1e-6, (1e-6, 2e-6] -> 5e-6, (0.2, +Inf) -> overflow
+	if dp.BucketCounts[0] != 1 || dp.BucketCounts[1] != 2 || dp.BucketCounts[len(dp.BucketCounts)-1] != 3 {
+		t.Errorf("unexpected bucket counts %v", dp.BucketCounts)
+	}
+	expectedSum := 1e-6 + 2*1.5e-6 + 3*0.2
+	if math.Abs(dp.Sum-expectedSum) > 1e-12 {
+		t.Errorf("expected sum %v, got %v", expectedSum, dp.Sum)
+	}
+}
@@ -0,0 +1,127 @@
+package metric
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// SLOWindowAttributeKey is the attribute holding the window, such as "1h",
+// of a burn rate gauge.
+const SLOWindowAttributeKey = "slo.window"
+
+// sloSlots is the number of slots of a rolling window, so the measurements
+// leave the window in steps of window/sloSlots.
+const sloSlots = 60
+
+// DefaultSLOWindows are the burn rate windows used when SLOOpts.Windows is
+// empty, a short one to page fast and a long one to ignore brief spikes.
+var DefaultSLOWindows = []time.Duration{5 * time.Minute, time.Hour}
+
+type (
+	// SLOOpts configures the burn rate gauges of a RED. The burn rates are
+	// computed from the calls of the process, so the gauges of replicas must
+	// not be averaged: compute the burn rate of a service from the sums of
+	// the requests and errors counters instead.
+	SLOOpts struct {
+		// Target is the objective of successful calls, such as 0.999.
+		Target float64
+		// Windows are the rolling windows the burn rates are computed over.
+		// Defaults to DefaultSLOWindows.
+		Windows []time.Duration
+	}
+
+	// slo counts the calls of an operation in rolling windows. The burn rate
+	// of a window is its error rate divided by the error budget, 1 - target:
+	// at 1 the budget is spent exactly over the SLO period. The calls are
+	// counted per process.
+	slo struct {
+		target  float64
+		windows []*sloWindow
+	}
+
+	sloWindow struct {
+		window time.Duration
+		width  time.Duration
+		mu     sync.Mutex
+		slots  [sloSlots]sloSlot
+	}
+
+	sloSlot struct {
+		index  int64
+		total  uint64
+		errors uint64
+	}
+)
+
+func newSLO(opts SLOOpts) (*slo, error) {
+	if opts.Target <= 0 || opts.Target >= 1 {
+		return nil, fmt.Errorf("target %v must be between 0 and 1, exclusive", opts.Target)
+	}
+	windows := opts.Windows
+	if len(windows) == 0 {
+		windows = DefaultSLOWindows
+	}
+
+	s := &slo{target: opts.Target}
+	for _, window := range windows {
+		if window <= 0 {
+			return nil, fmt.Errorf("window %s must be positive", window)
+		}
+		s.windows = append(s.windows, &sloWindow{window: window, width: max(window/sloSlots, 1)})
+	}
+	return s, nil
+}
+
+func (s *slo) add(now time.Time, failed bool) {
+	for _, w := range s.windows {
+		w.add(now, failed)
+	}
+}
+
+func (w *sloWindow) add(now time.Time, failed bool) {
+	index := now.UnixNano() / int64(w.width)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	slot := &w.slots[index%sloSlots]
+	if slot.index != index {
+		*slot = sloSlot{index: index}
+	}
+	slot.total++
+	if failed {
+		slot.errors++
+	}
+}
+
+// burnRate returns the burn rate over the window ending at now, 0 when there
+// were no calls.
+func (w *sloWindow) burnRate(now time.Time, target float64) float64 {
+	index := now.UnixNano() / int64(w.width)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var total, errs uint64
+	for _, slot := range w.slots {
+		if slot.index > index-sloSlots && slot.index <= index {
+			total += slot.total
+			errs += slot.errors
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(errs) / float64(total) / (1 - target)
+}
+
+// formatWindow formats d without its zero units, e.g. "5m" instead of "5m0s".
+func formatWindow(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	default:
+		return d.String()
+	}
+}
@@ -0,0 +1,56 @@
+package metric
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSLOWindow_Rolls(t *testing.T) {
+	s, err := newSLO(SLOOpts{Target: 0.99, Windows: []time.Duration{time.Minute}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	w := s.windows[0]
+	start := time.Unix(0, 0)
+
+	if rate := w.burnRate(start, s.target); rate != 0 {
+		t.Errorf("expected no burn without calls, got %v", rate)
+	}
+
+	s.add(start, true)
+	s.add(start, false)
+	if rate := w.burnRate(start.Add(30*time.Second), s.target); !equalRate(rate, 50) {
+		t.Errorf("expected a burn rate of 50, got %v", rate)
+	}
+
+	s.add(start.Add(45*time.Second), false)
+	s.add(start.Add(45*time.Second), false)
+	if rate := w.burnRate(start.Add(45*time.Second), s.target); !equalRate(rate, 25) {
+		t.Errorf("expected a burn rate of 25, got %v", rate)
+	}
+
+	// the first calls left the window
+	if rate := w.burnRate(start.Add(time.Minute+time.Second), s.target); rate != 0 {
+		t.Errorf("expected the errors to leave the window, got %v", rate)
+	}
+}
+
+func TestFormatWindow(t *testing.T) {
+	tests := map[time.Duration]string{
+		time.Hour:               "1h",
+		6 * time.Hour:           "6h",
+		5 * time.Minute:         "5m",
+		90 * time.Second:        "1m30s",
+		30 * time.Second:        "30s",
+		1500 * time.Millisecond: "1.5s",
+	}
+	for d, expected := range tests {
+		if got := formatWindow(d); got != expected {
+			t.Errorf("formatWindow(%v) = %s, expected %s", d, got, expected)
+		}
+	}
+}
+
+func equalRate(a, b float64) bool {
+	return a-b < 1e-9 && b-a < 1e-9
+}
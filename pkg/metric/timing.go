@@ -0,0 +1,115 @@
+package metric
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bruno303/go-toolkit/pkg/trace"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	// OutcomeAttributeKey is the attribute Measure records the outcome of the
+	// measured function with.
+	OutcomeAttributeKey = "outcome"
+	OutcomeSuccess      = "success"
+	OutcomeError        = "error"
+)
+
+// DurationBuckets are the default bucket boundaries, in seconds, of the
+// histograms recorded by Time and Measure.
+var DurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10}
+
+// MeasureOpts configures MeasureWithOpts.
+type MeasureOpts struct {
+	// Attributes are recorded with the duration, besides the outcome.
+	Attributes []Attribute
+	// Buckets of the histogram. Defaults to DurationBuckets.
+	Buckets []float64
+	// Trace, when set, runs the function in a span created with trace.Trace.
+	// Empty names default to the metric name split at its last dot, so
+	// "orders.checkout" creates the span "orders.checkout".
+	Trace *trace.TraceConfig
+}
+
+// Time starts measuring a duration recorded, in seconds, in the histogram
+// name of the global meter when the returned function is called:
+//
+//	defer metric.Time(ctx, "orders.checkout.duration")()
+//
+// The attributes given to stop are recorded besides attrs.
+func Time(ctx context.Context, name string, attrs ...Attribute) (stop func(attrs ...Attribute) error) {
+	start := time.Now()
+	return func(stopAttrs ...Attribute) error {
+		return recordDuration(ctx, name, DurationBuckets, time.Since(start), slices.Concat(attrs, stopAttrs))
+	}
+}
+
+// Measure calls fn and records its duration, in seconds, in the histogram
+// name of the global meter, with the outcome attribute set to success or
+// error. Recording errors, such as a conflicting declaration of name, are
+// reported to the OTel error handler instead of being returned; use Time to
+// handle them.
+func Measure[T any](ctx context.Context, name string, fn func(ctx context.Context) (T, error)) (T, error) {
+	return MeasureWithOpts(ctx, name, MeasureOpts{}, fn)
+}
+
+// MeasureWithOpts is Measure with extra attributes, custom buckets or a span
+// around fn. The duration is recorded within the span, so its exemplar links
+// to it.
+func MeasureWithOpts[T any](ctx context.Context, name string, opts MeasureOpts, fn func(ctx context.Context) (T, error)) (T, error) {
+	buckets := opts.Buckets
+	if len(buckets) == 0 {
+		buckets = DurationBuckets
+	}
+
+	measured := func(ctx context.Context) (T, error) {
+		start := time.Now()
+		result, err := fn(ctx)
+		elapsed := time.Since(start)
+
+		outcome := OutcomeSuccess
+		if err != nil {
+			outcome = OutcomeError
+		}
+		attrs := slices.Concat(opts.Attributes, []Attribute{NewAttribute(OutcomeAttributeKey, outcome)})
+		if err := recordDuration(ctx, name, buckets, elapsed, attrs); err != nil {
+			otel.Handle(fmt.Errorf("failed to record the duration of %s: %w", name, err))
+		}
+		return result, err
+	}
+	if opts.Trace == nil {
+		return measured(ctx)
+	}
+	return traced(ctx, spanConfig(name, *opts.Trace), measured)
+}
+
+// spanConfig fills the empty names of cfg with name split at its last dot.
+func spanConfig(name string, cfg trace.TraceConfig) *trace.TraceConfig {
+	traceName, spanName := name, name
+	if idx := strings.LastIndex(name, "."); idx >= 0 {
+		traceName, spanName = name[:idx], name[idx+1:]
+	}
+	if cfg.TraceName == "" {
+		cfg.TraceName = traceName
+	}
+	if cfg.SpanName == "" {
+		cfg.SpanName = spanName
+	}
+	return &cfg
+}
+
+func traced[T any](ctx context.Context, cfg *trace.TraceConfig, fn func(ctx context.Context) (T, error)) (T, error) {
+	res, err := trace.Trace(ctx, cfg, func(ctx context.Context) (any, error) {
+		return fn(ctx)
+	})
+	result, _ := res.(T)
+	return result, err
+}
+
+func recordDuration(ctx context.Context, name string, buckets []float64, elapsed time.Duration, attrs []Attribute) error {
+	return GetMeter().RecordHistogram(ctx, name, "", "s", buckets, elapsed.Seconds(), attrs...)
+}
@@ -0,0 +1,111 @@
+package metric_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/bruno303/go-toolkit/pkg/metric"
+	"github.com/bruno303/go-toolkit/pkg/metric/metrictest"
+	"github.com/bruno303/go-toolkit/pkg/trace"
+	"github.com/bruno303/go-toolkit/pkg/trace/tracetest"
+	"go.opentelemetry.io/otel"
+)
+
+func TestTime(t *testing.T) {
+	rec := metrictest.NewRecorder(t)
+	ctx := context.Background()
+	queue := metric.NewAttribute("queue", "orders")
+
+	stop := metric.Time(ctx, "job.duration", queue)
+	if err := stop(metric.NewAttribute("status", "done")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	attrs := []metric.Attribute{queue, metric.NewAttribute("status", "done")}
+	metrictest.AssertHistogramCount(t, rec, "job.duration", attrs, 1)
+	point, _ := rec.Histogram("job.duration", attrs...)
+	if !slices.Equal(point.Bounds, metric.DurationBuckets) {
+		t.Errorf("expected the duration buckets, got %v", point.Bounds)
+	}
+}
+
+func TestMeasure(t *testing.T) {
+	rec := metrictest.NewRecorder(t)
+	ctx := context.Background()
+	expectedErr := errors.New("failure")
+
+	got, err := metric.Measure(ctx, "orders.checkout", func(ctx context.Context) (int, error) {
+		return 42, nil
+	})
+	if err != nil || got != 42 {
+		t.Fatalf("expected 42, got %d, %v", got, err)
+	}
+	_, err = metric.Measure(ctx, "orders.checkout", func(ctx context.Context) (int, error) {
+		return 0, expectedErr
+	})
+	if !errors.Is(err, expectedErr) {
+		t.Fatalf("expected error %v, got %v", expectedErr, err)
+	}
+
+	success := []metric.Attribute{metric.NewAttribute(metric.OutcomeAttributeKey, metric.OutcomeSuccess)}
+	failure := []metric.Attribute{metric.NewAttribute(metric.OutcomeAttributeKey, metric.OutcomeError)}
+	metrictest.AssertHistogramCount(t, rec, "orders.checkout", success, 1)
+	metrictest.AssertHistogramCount(t, rec, "orders.checkout", failure, 1)
+}
+
+func TestMeasure_ReportsConflicts(t *testing.T) {
+	var reported []error
+	previous := otel.GetErrorHandler()
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) { reported = append(reported, err) }))
+	t.Cleanup(func() { otel.SetErrorHandler(previous) })
+
+	rec := metrictest.NewRecorder(t)
+	ctx := context.Background()
+	if _, err := rec.Meter().Counter("orders.checkout", metric.InstrumentOpts{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := metric.MeasureWithOpts(ctx, "orders.checkout", metric.MeasureOpts{Buckets: []float64{1}}, func(ctx context.Context) (int, error) {
+		return 42, nil
+	})
+	if err != nil || got != 42 {
+		t.Fatalf("expected 42, got %d, %v", got, err)
+	}
+	if len(reported) != 1 || !errors.Is(reported[0], metric.ErrInstrumentConflict) {
+		t.Errorf("expected the conflict to be reported, got %v", reported)
+	}
+}
+
+func TestMeasureWithOpts_Trace(t *testing.T) {
+	rec := metrictest.NewRecorder(t)
+	spans := tracetest.NewRecorder(t)
+	ctx := context.Background()
+
+	var traceIDs trace.TraceIDs
+	_, err := metric.MeasureWithOpts(ctx, "orders.checkout", metric.MeasureOpts{
+		Attributes: []metric.Attribute{metric.NewAttribute("method", "card")},
+		Buckets:    []float64{1, 10},
+		Trace:      &trace.TraceConfig{},
+	}, func(ctx context.Context) (string, error) {
+		traceIDs = trace.ExtractTraceIds(ctx)
+		return "ok", nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tracetest.AssertSpanNames(t, spans, "orders.checkout")
+	if !traceIDs.IsValid {
+		t.Error("expected fn to run within the span")
+	}
+	attrs := []metric.Attribute{
+		metric.NewAttribute("method", "card"),
+		metric.NewAttribute(metric.OutcomeAttributeKey, metric.OutcomeSuccess),
+	}
+	point, ok := rec.Histogram("orders.checkout", attrs...)
+	if !ok || point.Count != 1 || !slices.Equal(point.Bounds, []float64{1, 10}) {
+		t.Errorf("unexpected histogram %+v", point)
+	}
+}
@@ -0,0 +1,196 @@
+package metric
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/bruno303/go-toolkit/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// OverflowAttributeKey marks the series receiving the measurements of the
+// attribute sets beyond the cardinality limit of an instrument.
+const OverflowAttributeKey = "otel.metric.overflow"
+
+var overflowSet = attribute.NewSet(attribute.Bool(OverflowAttributeKey, true))
+
+type (
+	// View changes how the instruments matching Instrument are exported. An
+	// instrument must match a single view: creating an instrument matching
+	// several ones fails, as each matching view would export its own stream.
+	View struct {
+		// Instrument is the name of the instruments the view applies to, where
+		// '*' matches any sequence of characters.
+		Instrument string
+		// Name renames the instrument, which requires Instrument to have no
+		// wildcard.
+		Name        string
+		Description string
+		// AllowedAttributes keeps only the listed attribute keys and
+		// DroppedAttributes removes the listed ones.
+		AllowedAttributes []string
+		DroppedAttributes []string
+		// Buckets replaces the bucket boundaries of histograms. A view with
+		// Buckets only applies to histograms.
+		Buckets []float64
+		// CardinalityLimit overrides the default limit of the meter for the
+		// matching instruments.
+		CardinalityLimit int
+	}
+
+	// cardinalityLimiter records the attribute sets of an instrument and
+	// replaces the new ones by the overflow set once limit is reached.
+	cardinalityLimiter struct {
+		name       string
+		limit      int
+		filter     attribute.Filter
+		onOverflow func(ctx context.Context, instrument string, limit int)
+		once       sync.Once
+		mu         sync.Mutex
+		count      int
+		sets       sync.Map
+	}
+)
+
+func (v View) validate() error {
+	if v.Instrument == "" {
+		return errors.New("view instrument name is required")
+	}
+	if v.Name != "" && strings.Contains(v.Instrument, "*") {
+		return fmt.Errorf("view %s: renaming requires an instrument name without wildcards", v.Instrument)
+	}
+	if v.CardinalityLimit < 0 {
+		return fmt.Errorf("view %s: negative cardinality limit %d", v.Instrument, v.CardinalityLimit)
+	}
+	return nil
+}
+
+func (v View) sdkView() sdkmetric.View {
+	criteria := sdkmetric.Instrument{Name: v.Instrument}
+	stream := sdkmetric.Stream{
+		Name:            v.Name,
+		Description:     v.Description,
+		AttributeFilter: v.attributeFilter(),
+	}
+	if len(v.Buckets) > 0 {
+		criteria.Kind = sdkmetric.InstrumentKindHistogram
+		stream.Aggregation = sdkmetric.AggregationExplicitBucketHistogram{Boundaries: v.Buckets}
+	}
+	return sdkmetric.NewView(criteria, stream)
+}
+
+// attributeFilter returns the filter of the allowed and dropped keys, which
+// always keeps the overflow attribute, or nil when the view keeps them all.
+func (v View) attributeFilter() attribute.Filter {
+	if len(v.AllowedAttributes) == 0 && len(v.DroppedAttributes) == 0 {
+		return nil
+	}
+	return func(kv attribute.KeyValue) bool {
+		key := string(kv.Key)
+		if key == OverflowAttributeKey {
+			return true
+		}
+		if len(v.AllowedAttributes) > 0 && !slices.Contains(v.AllowedAttributes, key) {
+			return false
+		}
+		return !slices.Contains(v.DroppedAttributes, key)
+	}
+}
+
+func (v View) matches(info InstrumentInfo) bool {
+	if len(v.Buckets) > 0 && info.Kind != InstrumentHistogram && info.Kind != InstrumentInt64Histogram {
+		return false
+	}
+	return telemetry.MatchWildcard(v.Instrument, info.Name)
+}
+
+// checkViews rejects the instruments matching more than one view, as the SDK
+// would export a stream per view while the cardinality limit follows a single
+// one.
+func (m *OtelMeter) checkViews(info InstrumentInfo) error {
+	var matched []string
+	for _, view := range m.views {
+		if view.matches(info) {
+			matched = append(matched, view.Instrument)
+		}
+	}
+	if len(matched) > 1 {
+		return fmt.Errorf("instrument %s matches the views %s, but it must match a single view",
+			info.Name, strings.Join(matched, ", "))
+	}
+	return nil
+}
+
+// newCardinalityLimiter returns the limiter of the instrument described by
+// info, or nil when it has no limit. checkViews ensures a single view
+// matches.
+func (m *OtelMeter) newCardinalityLimiter(info InstrumentInfo) *cardinalityLimiter {
+	limiter := &cardinalityLimiter{
+		name:       info.Name,
+		limit:      m.cardinalityLimit,
+		onOverflow: m.onOverflow,
+	}
+	for _, view := range m.views {
+		if view.matches(info) {
+			limiter.filter = view.attributeFilter()
+			if view.CardinalityLimit > 0 {
+				limiter.limit = view.CardinalityLimit
+			}
+			break
+		}
+	}
+	if limiter.limit <= 0 {
+		return nil
+	}
+	return limiter
+}
+
+// apply returns set, or the overflow set when set is new and the limit is
+// reached. The overflow series counts towards the limit. Sets are compared
+// after the attribute filter of the view, so dropped keys do not count.
+func (l *cardinalityLimiter) apply(ctx context.Context, set attribute.Set) attribute.Set {
+	filtered := set
+	if l.filter != nil {
+		filtered, _ = set.Filter(l.filter)
+	}
+	key := filtered.Equivalent()
+	if _, ok := l.sets.Load(key); ok {
+		return set
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.sets.Load(key); ok {
+		return set
+	}
+	if l.count >= l.limit-1 {
+		l.once.Do(func() {
+			if l.onOverflow != nil {
+				l.onOverflow(ctx, l.name, l.limit)
+			}
+		})
+		return overflowSet
+	}
+	l.sets.Store(key, struct{}{})
+	l.count++
+	return set
+}
+
+// limitedAttributeSet converts attrs, applying the limit of limiter when it
+// is not nil.
+func limitedAttributeSet(ctx context.Context, limiter *cardinalityLimiter, attrs []Attribute) attribute.Set {
+	return limiter.limitSet(ctx, toAttributeSet(attrs))
+}
+
+// limitSet applies the limit of l to set, returned as is when l is nil.
+func (l *cardinalityLimiter) limitSet(ctx context.Context, set attribute.Set) attribute.Set {
+	if l == nil {
+		return set
+	}
+	return l.apply(ctx, set)
+}
@@ -0,0 +1,221 @@
+package metric
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func newTestOtelMeterWithViews(t *testing.T, opts OtelMeterOpts) (*OtelMeter, *sdkmetric.ManualReader) {
+	t.Helper()
+	reader := sdkmetric.NewManualReader()
+	providerOpts := []sdkmetric.Option{sdkmetric.WithReader(reader)}
+	for _, view := range opts.Views {
+		providerOpts = append(providerOpts, sdkmetric.WithView(view.sdkView()))
+	}
+	provider := sdkmetric.NewMeterProvider(providerOpts...)
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+	return NewOtelMeterWithOpts(provider.Meter("test"), opts), reader
+}
+
+func TestView_RenameFilterAndBuckets(t *testing.T) {
+	ctx := context.Background()
+	meter, reader := newTestOtelMeterWithViews(t, OtelMeterOpts{Views: []View{
+		{Instrument: "requests", Name: "http.server.requests", DroppedAttributes: []string{"user.id"}},
+		{Instrument: "*.duration", AllowedAttributes: []string{"method"}, Buckets: []float64{1, 10}},
+	}})
+
+	_ = meter.AddCounter(ctx, "requests", "Requests", "1", 1, NewAttribute("method", "GET"), NewAttribute("user.id", "42"))
+	_ = meter.RecordHistogram(ctx, "http.duration", "Duration", "s", nil, 5,
+		NewAttribute("method", "GET"), NewAttribute("path", "/users/42"))
+
+	got := collect(t, reader)
+	if _, ok := got["requests"]; ok {
+		t.Error("expected the counter to be renamed")
+	}
+	requests, ok := got["http.server.requests"].(metricdata.Sum[float64])
+	if !ok || len(requests.DataPoints) != 1 {
+		t.Fatalf("expected the renamed counter, got %+v", got["http.server.requests"])
+	}
+	if _, ok := requests.DataPoints[0].Attributes.Value("user.id"); ok {
+		t.Error("expected user.id to be dropped")
+	}
+
+	duration, ok := got["http.duration"].(metricdata.Histogram[float64])
+	if !ok || len(duration.DataPoints) != 1 {
+		t.Fatalf("expected the histogram, got %+v", got["http.duration"])
+	}
+	dp := duration.DataPoints[0]
+	if !slices.Equal(dp.Bounds, []float64{1, 10}) {
+		t.Errorf("expected the view buckets, got %v", dp.Bounds)
+	}
+	if dp.Attributes.Len() != 1 {
+		t.Errorf("expected only the method attribute, got %v", dp.Attributes.ToSlice())
+	}
+}
+
+func TestView_BucketsOnlyApplyToHistograms(t *testing.T) {
+	ctx := context.Background()
+	meter, reader := newTestOtelMeterWithViews(t, OtelMeterOpts{Views: []View{
+		{Instrument: "*", Buckets: []float64{1, 10}},
+	}})
+
+	_ = meter.AddCounter(ctx, "requests", "Requests", "1", 1)
+
+	if _, ok := collect(t, reader)["requests"].(metricdata.Sum[float64]); !ok {
+		t.Error("expected the counter to keep its sum aggregation")
+	}
+}
+
+func TestOtelMeter_RejectsOverlappingViews(t *testing.T) {
+	ctx := context.Background()
+	meter, _ := newTestOtelMeterWithViews(t, OtelMeterOpts{Views: []View{
+		{Instrument: "http.*", DroppedAttributes: []string{"user.id"}},
+		{Instrument: "http.server.requests", CardinalityLimit: 10},
+		{Instrument: "*.duration", Buckets: []float64{1, 10}},
+	}})
+
+	if err := meter.AddCounter(ctx, "http.server.requests", "Requests", "1", 1); err == nil {
+		t.Error("expected an error for an instrument matching two views")
+	}
+	if _, err := meter.Counter("http.client.requests", InstrumentOpts{}); err != nil {
+		t.Errorf("unexpected error for a single view: %v", err)
+	}
+	// the buckets view only matches histograms
+	if err := meter.AddCounter(ctx, "http.server.duration", "Duration", "s", 1); err != nil {
+		t.Errorf("unexpected error for a counter: %v", err)
+	}
+	if err := meter.RecordHistogram(ctx, "http.client.duration", "Duration", "s", nil, 1); err == nil {
+		t.Error("expected an error for a histogram matching two views")
+	}
+}
+
+func TestOtelMeter_CardinalityLimit(t *testing.T) {
+	ctx := context.Background()
+	var overflows []string
+	meter, reader := newTestOtelMeterWithViews(t, OtelMeterOpts{
+		CardinalityLimit: 3,
+		OnOverflow: func(_ context.Context, instrument string, limit int) {
+			overflows = append(overflows, fmt.Sprintf("%s:%d", instrument, limit))
+		},
+	})
+
+	for i := range 5 {
+		_ = meter.AddCounter(ctx, "requests", "Requests", "1", 1, NewAttribute("user.id", i))
+	}
+	_ = meter.AddCounter(ctx, "requests", "Requests", "1", 1, NewAttribute("user.id", 0))
+
+	sum := collect(t, reader)["requests"].(metricdata.Sum[float64])
+	if len(sum.DataPoints) != 3 {
+		t.Fatalf("expected 2 series and the overflow one, got %d", len(sum.DataPoints))
+	}
+	for _, dp := range sum.DataPoints {
+		if dp.Attributes.Equals(&overflowSet) {
+			if dp.Value != 3 {
+				t.Errorf("expected 3 overflowing measurements, got %v", dp.Value)
+			}
+		} else if id, _ := dp.Attributes.Value("user.id"); id.AsInt64() == 0 && dp.Value != 2 {
+			t.Errorf("expected known sets to keep recording, got %v", dp.Value)
+		}
+	}
+	if !slices.Equal(overflows, []string{"requests:3"}) {
+		t.Errorf("expected a single overflow notification, got %v", overflows)
+	}
+}
+
+func TestOtelMeter_CardinalityLimitPerView(t *testing.T) {
+	ctx := context.Background()
+	meter, reader := newTestOtelMeterWithViews(t, OtelMeterOpts{
+		CardinalityLimit: 2,
+		Views: []View{
+			{Instrument: "requests", DroppedAttributes: []string{"user.id"}, CardinalityLimit: 3},
+		},
+	})
+
+	for i := range 10 {
+		_ = meter.AddCounter(ctx, "requests", "Requests", "1", 1, NewAttribute("method", "GET"), NewAttribute("user.id", i))
+		_ = meter.AddCounter(ctx, "requests", "Requests", "1", 1, NewAttribute("method", "POST"), NewAttribute("user.id", i))
+		_ = meter.AddCounter(ctx, "jobs", "Jobs", "1", 1, NewAttribute("job", i))
+	}
+
+	got := collect(t, reader)
+	requests := got["requests"].(metricdata.Sum[float64])
+	for _, dp := range requests.DataPoints {
+		if dp.Attributes.Equals(&overflowSet) {
+			t.Error("expected dropped attributes not to count towards the limit")
+		}
+	}
+	if len(requests.DataPoints) != 2 {
+		t.Errorf("expected a series per method, got %d", len(requests.DataPoints))
+	}
+	if jobs := got["jobs"].(metricdata.Sum[float64]); len(jobs.DataPoints) != 2 {
+		t.Errorf("expected the default limit for jobs, got %d series", len(jobs.DataPoints))
+	}
+}
+
+func TestOtelMeter_CardinalityLimitObservable(t *testing.T) {
+	meter, reader := newTestOtelMeterWithViews(t, OtelMeterOpts{CardinalityLimit: 2})
+
+	_, err := meter.ObserveGauge("queue.size", "Queue size", "1", func(ctx context.Context, o Observer) error {
+		for _, queue := range []string{"a", "b", "c"} {
+			o.Observe(1, NewAttribute("queue", queue))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	gauge := collect(t, reader)["queue.size"].(metricdata.Gauge[float64])
+	if len(gauge.DataPoints) != 2 {
+		t.Errorf("expected 1 series and the overflow one, got %d", len(gauge.DataPoints))
+	}
+}
+
+func TestView_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		view    View
+		wantErr bool
+	}{
+		{name: "valid", view: View{Instrument: "http.*", DroppedAttributes: []string{"user.id"}}},
+		{name: "rename", view: View{Instrument: "requests", Name: "http.requests"}},
+		{name: "missing instrument", view: View{Name: "http.requests"}, wantErr: true},
+		{name: "rename with wildcard", view: View{Instrument: "http.*", Name: "requests"}, wantErr: true},
+		{name: "negative limit", view: View{Instrument: "requests", CardinalityLimit: -1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.view.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestView_AttributeFilterKeepsOverflow(t *testing.T) {
+	filter := View{Instrument: "requests", AllowedAttributes: []string{"method"}}.attributeFilter()
+	if !filter(attribute.Bool(OverflowAttributeKey, true)) {
+		t.Error("expected the overflow attribute to be kept")
+	}
+	if filter(attribute.String("path", "/")) {
+		t.Error("expected path to be filtered")
+	}
+}
+
+func TestNewMeterProvider_InvalidViews(t *testing.T) {
+	ctx := context.Background()
+	for _, cfg := range []Config{
+		{Exporters: []ExporterType{ExporterNone}, DisableEnv: true, Views: []View{{Instrument: "*", Name: "requests"}}},
+		{Exporters: []ExporterType{ExporterNone}, DisableEnv: true, CardinalityLimit: -1},
+	} {
+		if _, _, err := newMeterProvider(ctx, &cfg); err == nil {
+			t.Errorf("expected an error for %+v", cfg)
+		}
+	}
+}
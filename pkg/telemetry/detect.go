@@ -0,0 +1,131 @@
+package telemetry
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+var (
+	cgroupPath              = "/proc/self/cgroup"
+	mountInfoPath           = "/proc/self/mountinfo"
+	downwardAPIDir          = "/etc/podinfo"
+	serviceAccountNamespace = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+	containerIDRegex      = regexp.MustCompile(`[0-9a-f]{64}`)
+	mountContainerIDRegex = regexp.MustCompile(`containers/([0-9a-f]{64})/`)
+)
+
+var (
+	_ resource.Detector = containerDetector{}
+	_ resource.Detector = kubernetesDetector{}
+)
+
+// containerDetector reads the container id from the cgroup (v1) or mount
+// information (v2) of the current process.
+type containerDetector struct{}
+
+func (containerDetector) Detect(context.Context) (*resource.Resource, error) {
+	id := containerID()
+	if id == "" {
+		return resource.Empty(), nil
+	}
+	return resource.NewWithAttributes(semconv.SchemaURL, semconv.ContainerID(id)), nil
+}
+
+func containerID() string {
+	if id := scanFile(cgroupPath, func(line string) string {
+		// hierarchy-ID:controller-list:cgroup-path
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			return ""
+		}
+		return containerIDRegex.FindString(filepath.Base(parts[2]))
+	}); id != "" {
+		return id
+	}
+	return scanFile(mountInfoPath, func(line string) string {
+		if m := mountContainerIDRegex.FindStringSubmatch(line); m != nil {
+			return m[1]
+		}
+		return ""
+	})
+}
+
+func scanFile(path string, match func(line string) string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if v := match(scanner.Text()); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// kubernetesDetector reads the pod metadata from the K8S_* env vars, usually
+// filled with the downward API, falling back to downward API volume files
+// mounted at /etc/podinfo and to the service account namespace.
+type kubernetesDetector struct{}
+
+func (kubernetesDetector) Detect(context.Context) (*resource.Resource, error) {
+	attrs := make([]attribute.KeyValue, 0, 4)
+
+	podName := firstNonEmpty(os.Getenv("K8S_POD_NAME"), readTrimmed(filepath.Join(downwardAPIDir, "name")))
+	if podName == "" && os.Getenv("KUBERNETES_SERVICE_HOST") != "" {
+		podName, _ = os.Hostname()
+	}
+	if podName != "" {
+		attrs = append(attrs, semconv.K8SPodName(podName))
+	}
+
+	namespace := firstNonEmpty(
+		os.Getenv("K8S_NAMESPACE_NAME"),
+		readTrimmed(filepath.Join(downwardAPIDir, "namespace")),
+		readTrimmed(serviceAccountNamespace),
+	)
+	if namespace != "" {
+		attrs = append(attrs, semconv.K8SNamespaceName(namespace))
+	}
+
+	if uid := firstNonEmpty(os.Getenv("K8S_POD_UID"), readTrimmed(filepath.Join(downwardAPIDir, "uid"))); uid != "" {
+		attrs = append(attrs, semconv.K8SPodUID(uid))
+	}
+	if node := os.Getenv("K8S_NODE_NAME"); node != "" {
+		attrs = append(attrs, semconv.K8SNodeName(node))
+	}
+
+	if len(attrs) == 0 {
+		return resource.Empty(), nil
+	}
+	return resource.NewWithAttributes(semconv.SchemaURL, attrs...), nil
+}
+
+func readTrimmed(path string) string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(content))
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,106 @@
+package telemetry
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testContainerID = "3f4e1c2d9b8a7f6e5d4c3b2a1f0e9d8c7b6a5f4e3d2c1b0a9f8e7d6c5b4a3f2e"
+
+func writeFile(t *testing.T, dir string, name string, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+	return path
+}
+
+func setPath(t *testing.T, target *string, value string) {
+	t.Helper()
+	previous := *target
+	*target = value
+	t.Cleanup(func() { *target = previous })
+}
+
+func TestContainerID(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name      string
+		cgroup    string
+		mountInfo string
+	}{
+		{"cgroup v1 docker", "12:memory:/docker/" + testContainerID + "\n", ""},
+		{"cgroup v1 systemd", "1:name=systemd:/system.slice/docker-" + testContainerID + ".scope\n", ""},
+		{"cgroup v2", "0::/\n", "1 2 0:1 /var/lib/docker/containers/" + testContainerID + "/hostname /etc/hostname rw - ext4 /dev/sda1 rw\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setPath(t, &cgroupPath, writeFile(t, dir, "cgroup", tt.cgroup))
+			setPath(t, &mountInfoPath, writeFile(t, dir, "mountinfo", tt.mountInfo))
+
+			if id := containerID(); id != testContainerID {
+				t.Errorf("expected container id %s, got %s", testContainerID, id)
+			}
+		})
+	}
+
+	t.Run("not in a container", func(t *testing.T) {
+		setPath(t, &cgroupPath, writeFile(t, dir, "cgroup", "0::/\n"))
+		setPath(t, &mountInfoPath, filepath.Join(dir, "missing"))
+
+		if id := containerID(); id != "" {
+			t.Errorf("expected no container id, got %s", id)
+		}
+	})
+}
+
+func TestKubernetesDetector(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "name", "api-7d9f-abc\n")
+	writeFile(t, dir, "namespace", "payments\n")
+	setPath(t, &downwardAPIDir, dir)
+	setPath(t, &serviceAccountNamespace, filepath.Join(dir, "missing"))
+	t.Setenv("K8S_POD_NAME", "")
+	t.Setenv("K8S_NAMESPACE_NAME", "")
+	t.Setenv("K8S_NODE_NAME", "node-1")
+
+	res, err := NewResource(context.Background(), Service{Name: "app"}, ResourceConfig{Kubernetes: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		"k8s.pod.name":       "api-7d9f-abc",
+		"k8s.namespace.name": "payments",
+		"k8s.node.name":      "node-1",
+	}
+	for k, v := range expected {
+		if got, _ := attributeValue(res, k); got != v {
+			t.Errorf("expected %s to be %s, got %s", k, v, got)
+		}
+	}
+
+	t.Setenv("K8S_POD_NAME", "from-env")
+	res, _ = NewResource(context.Background(), Service{Name: "app"}, ResourceConfig{Kubernetes: true})
+	if got, _ := attributeValue(res, "k8s.pod.name"); got != "from-env" {
+		t.Errorf("expected env var to take precedence, got %s", got)
+	}
+}
+
+func TestContainerDetector(t *testing.T) {
+	dir := t.TempDir()
+	setPath(t, &cgroupPath, writeFile(t, dir, "cgroup", "12:memory:/docker/"+testContainerID+"\n"))
+
+	res, err := NewResource(context.Background(), Service{Name: "app"}, ResourceConfig{Container: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, _ := attributeValue(res, "container.id"); got != testContainerID {
+		t.Errorf("expected container.id %s, got %s", testContainerID, got)
+	}
+}
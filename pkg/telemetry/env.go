@@ -0,0 +1,148 @@
+package telemetry
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Standard OpenTelemetry environment variables shared by the trace and metric setup.
+const (
+	EnvServiceName     = "OTEL_SERVICE_NAME"
+	EnvOTLPEndpoint    = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	EnvOTLPProtocol    = "OTEL_EXPORTER_OTLP_PROTOCOL"
+	EnvOTLPHeaders     = "OTEL_EXPORTER_OTLP_HEADERS"
+	EnvOTLPCompression = "OTEL_EXPORTER_OTLP_COMPRESSION"
+	EnvOTLPTimeout     = "OTEL_EXPORTER_OTLP_TIMEOUT"
+	EnvOTLPCertificate = "OTEL_EXPORTER_OTLP_CERTIFICATE"
+	EnvOTLPClientCert  = "OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE"
+	EnvOTLPClientKey   = "OTEL_EXPORTER_OTLP_CLIENT_KEY"
+	EnvOTLPInsecure    = "OTEL_EXPORTER_OTLP_INSECURE"
+	EnvPrometheusPort  = "OTEL_EXPORTER_PROMETHEUS_PORT"
+
+	ProtocolGRPC         = "grpc"
+	ProtocolHTTPProtobuf = "http/protobuf"
+)
+
+// SignalEnv returns the signal specific variant of an OTLP variable, e.g.
+// OTEL_EXPORTER_OTLP_TRACES_ENDPOINT for OTEL_EXPORTER_OTLP_ENDPOINT and "TRACES".
+func SignalEnv(key string, signal string) string {
+	return strings.Replace(key, "OTEL_EXPORTER_OTLP_", "OTEL_EXPORTER_OTLP_"+signal+"_", 1)
+}
+
+// Getenv returns the value of the first non-empty variable among keys.
+func Getenv(keys ...string) string {
+	for _, key := range keys {
+		if v := strings.TrimSpace(os.Getenv(key)); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// OTLPEnv holds the OTLP exporter settings read from the environment for a signal.
+type OTLPEnv struct {
+	Protocol    string
+	Endpoint    string
+	URLPath     string
+	Secure      bool
+	Insecure    bool
+	Headers     map[string]string
+	Compression string
+	Timeout     time.Duration
+	Certificate string
+	ClientCert  string
+	ClientKey   string
+}
+
+// LookupOTLPEnv reads the OTLP exporter variables for signal ("TRACES" or
+// "METRICS"), giving precedence to the signal specific ones. defaultPath is
+// appended to the path of the generic endpoint, as the specification requires
+// for OTLP HTTP.
+func LookupOTLPEnv(signal string, defaultPath string) (OTLPEnv, error) {
+	env := OTLPEnv{
+		Protocol:    Getenv(SignalEnv(EnvOTLPProtocol, signal), EnvOTLPProtocol),
+		Compression: Getenv(SignalEnv(EnvOTLPCompression, signal), EnvOTLPCompression),
+		Certificate: Getenv(SignalEnv(EnvOTLPCertificate, signal), EnvOTLPCertificate),
+		ClientCert:  Getenv(SignalEnv(EnvOTLPClientCert, signal), EnvOTLPClientCert),
+		ClientKey:   Getenv(SignalEnv(EnvOTLPClientKey, signal), EnvOTLPClientKey),
+	}
+
+	if v := Getenv(SignalEnv(EnvOTLPEndpoint, signal)); v != "" {
+		if err := env.setEndpoint(v, ""); err != nil {
+			return env, err
+		}
+	} else if v := Getenv(EnvOTLPEndpoint); v != "" {
+		if err := env.setEndpoint(v, defaultPath); err != nil {
+			return env, err
+		}
+	}
+
+	if v := Getenv(SignalEnv(EnvOTLPInsecure, signal), EnvOTLPInsecure); v != "" {
+		insecure, err := strconv.ParseBool(v)
+		if err != nil {
+			return env, fmt.Errorf("invalid %s: %w", EnvOTLPInsecure, err)
+		}
+		env.Insecure = insecure
+	}
+
+	if v := Getenv(SignalEnv(EnvOTLPHeaders, signal), EnvOTLPHeaders); v != "" {
+		headers, err := ParseHeaders(v)
+		if err != nil {
+			return env, err
+		}
+		env.Headers = headers
+	}
+
+	if v := Getenv(SignalEnv(EnvOTLPTimeout, signal), EnvOTLPTimeout); v != "" {
+		ms, err := strconv.Atoi(v)
+		if err != nil {
+			return env, fmt.Errorf("invalid %s: %w", EnvOTLPTimeout, err)
+		}
+		env.Timeout = time.Duration(ms) * time.Millisecond
+	}
+
+	return env, nil
+}
+
+func (e *OTLPEnv) setEndpoint(raw string, defaultPath string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("invalid OTLP endpoint %q, expected an URL such as http://collector:4317", raw)
+	}
+	e.Endpoint = u.Host
+	e.Secure = u.Scheme == "https"
+
+	if path := strings.TrimSuffix(u.Path, "/"); path != "" {
+		e.URLPath = path + defaultPath
+	}
+	return nil
+}
+
+// ParseHeaders parses a W3C baggage-like list of headers, such as
+// "api-key=secret,tenant=acme", as used by OTEL_EXPORTER_OTLP_HEADERS.
+func ParseHeaders(s string) (map[string]string, error) {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		key, value, found := strings.Cut(pair, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid header %q, expected key=value", pair)
+		}
+		key, err := url.PathUnescape(strings.TrimSpace(key))
+		if err != nil {
+			return nil, fmt.Errorf("invalid header key %q: %w", key, err)
+		}
+		value, err = url.PathUnescape(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid header value for %s: %w", key, err)
+		}
+		headers[key] = value
+	}
+	return headers, nil
+}
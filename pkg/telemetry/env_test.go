@@ -0,0 +1,98 @@
+package telemetry
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLookupOTLPEnv(t *testing.T) {
+	t.Setenv(EnvOTLPEndpoint, "https://collector:4318/otlp")
+	t.Setenv(EnvOTLPProtocol, ProtocolHTTPProtobuf)
+	t.Setenv(EnvOTLPHeaders, "api-key=secret,tenant=acme%20corp")
+	t.Setenv(EnvOTLPTimeout, "2500")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_COMPRESSION", "gzip")
+	t.Setenv(EnvOTLPCompression, "none")
+
+	env, err := LookupOTLPEnv("TRACES", "/v1/traces")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if env.Endpoint != "collector:4318" || env.URLPath != "/otlp/v1/traces" || !env.Secure {
+		t.Errorf("unexpected endpoint %s%s (secure: %v)", env.Endpoint, env.URLPath, env.Secure)
+	}
+	if env.Protocol != ProtocolHTTPProtobuf {
+		t.Errorf("expected protocol %s, got %s", ProtocolHTTPProtobuf, env.Protocol)
+	}
+	if env.Headers["api-key"] != "secret" || env.Headers["tenant"] != "acme corp" {
+		t.Errorf("unexpected headers %v", env.Headers)
+	}
+	if env.Timeout != 2500*time.Millisecond {
+		t.Errorf("expected timeout 2.5s, got %v", env.Timeout)
+	}
+	if env.Compression != "gzip" {
+		t.Errorf("expected signal specific compression to win, got %s", env.Compression)
+	}
+}
+
+func TestLookupOTLPEnv_SignalEndpoint(t *testing.T) {
+	t.Setenv(EnvOTLPEndpoint, "http://generic:4318")
+	t.Setenv("OTEL_EXPORTER_OTLP_METRICS_ENDPOINT", "http://metrics:4318/custom/path")
+
+	env, err := LookupOTLPEnv("METRICS", "/v1/metrics")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if env.Endpoint != "metrics:4318" || env.URLPath != "/custom/path" || env.Secure {
+		t.Errorf("unexpected endpoint %s%s (secure: %v)", env.Endpoint, env.URLPath, env.Secure)
+	}
+}
+
+func TestLookupOTLPEnv_Errors(t *testing.T) {
+	tests := []struct {
+		key   string
+		value string
+	}{
+		{EnvOTLPEndpoint, "collector:4317"},
+		{EnvOTLPTimeout, "10s"},
+		{EnvOTLPInsecure, "maybe"},
+		{EnvOTLPHeaders, "invalid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			t.Setenv(tt.key, tt.value)
+			if _, err := LookupOTLPEnv("TRACES", "/v1/traces"); err == nil {
+				t.Errorf("expected error for %s=%s", tt.key, tt.value)
+			}
+		})
+	}
+}
+
+func TestGetenv(t *testing.T) {
+	t.Setenv("FIRST", "")
+	t.Setenv("SECOND", " value ")
+
+	if v := Getenv("FIRST", "SECOND"); v != "value" {
+		t.Errorf("expected 'value', got %q", v)
+	}
+	if v := Getenv("FIRST"); v != "" {
+		t.Errorf("expected empty value, got %q", v)
+	}
+}
+
+func TestNewResourceWithoutEnv(t *testing.T) {
+	t.Setenv(EnvServiceName, "from-env")
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "team=payments")
+
+	res, err := NewResourceWithoutEnv(t.Context(), Service{}, ResourceConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := attributeValue(res, "service.name"); ok {
+		t.Error("expected service name to not be read from env")
+	}
+	if _, ok := attributeValue(res, "team"); ok {
+		t.Error("expected resource attributes to not be read from env")
+	}
+}
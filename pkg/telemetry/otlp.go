@@ -0,0 +1,114 @@
+package telemetry
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	CompressionNone = "none"
+	CompressionGzip = "gzip"
+)
+
+type (
+	// OTLPConfig holds the connection settings of the OTLP exporters, shared
+	// by the trace and metric setup.
+	OTLPConfig struct {
+		// Endpoint is the collector address, such as "localhost:4317".
+		Endpoint string `yaml:"endpoint" env:"ENDPOINT"`
+		// Headers are sent with every export request (e.g. authorization).
+		Headers map[string]string `yaml:"headers" env:"HEADERS"`
+		// Compression is the payload compression, "gzip" or "none".
+		Compression string `yaml:"compression" env:"COMPRESSION"`
+		// Timeout bounds each export request. Zero uses the exporter default.
+		Timeout time.Duration `yaml:"timeout" env:"TIMEOUT"`
+		// URLPath overrides the OTLP HTTP path of the signal.
+		URLPath string `yaml:"url_path" env:"URL_PATH"`
+		// TLS enables a secure connection to the endpoint.
+		TLS TLSConfig `yaml:"tls" env:", prefix=TLS_"`
+	}
+
+	TLSConfig struct {
+		Enabled            bool   `yaml:"enabled" env:"ENABLED"`
+		CAFile             string `yaml:"ca_file" env:"CA_FILE"`
+		CertFile           string `yaml:"cert_file" env:"CERT_FILE"`
+		KeyFile            string `yaml:"key_file" env:"KEY_FILE"`
+		ServerName         string `yaml:"server_name" env:"SERVER_NAME"`
+		InsecureSkipVerify bool   `yaml:"insecure_skip_verify" env:"INSECURE_SKIP_VERIFY"`
+	}
+)
+
+// ApplyEnv fills the empty fields of c with the values read by LookupOTLPEnv.
+func (c *OTLPConfig) ApplyEnv(env OTLPEnv) {
+	if c.Endpoint == "" && env.Endpoint != "" {
+		c.Endpoint = env.Endpoint
+		if c.URLPath == "" {
+			c.URLPath = env.URLPath
+		}
+		if env.Secure && !env.Insecure {
+			c.TLS.Enabled = true
+		}
+	}
+	if c.TLS.CAFile == "" && c.TLS.CertFile == "" && c.TLS.KeyFile == "" &&
+		(env.Certificate != "" || env.ClientCert != "") {
+		c.TLS.CAFile = env.Certificate
+		c.TLS.CertFile = env.ClientCert
+		c.TLS.KeyFile = env.ClientKey
+		c.TLS.Enabled = !env.Insecure
+	}
+	if c.Headers == nil {
+		c.Headers = env.Headers
+	}
+	if c.Compression == "" {
+		c.Compression = env.Compression
+	}
+	if c.Timeout == 0 {
+		c.Timeout = env.Timeout
+	}
+}
+
+// UseGzip reports whether compression, "gzip" or "none", enables gzip. An
+// empty compression is "none".
+func UseGzip(compression string) (bool, error) {
+	switch compression {
+	case "", CompressionNone:
+		return false, nil
+	case CompressionGzip:
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown OTLP compression %q", compression)
+	}
+}
+
+// Build returns the *tls.Config described by c.
+func (c TLSConfig) Build() (*tls.Config, error) {
+	tlsCfg := &tls.Config{
+		ServerName:         c.ServerName,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+
+	if c.CAFile != "" {
+		caPEM, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file %s: %w", c.CAFile, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no certificates found in CA file %s", c.CAFile)
+		}
+		tlsCfg.RootCAs = pool
+	}
+
+	if c.CertFile != "" || c.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsCfg, nil
+}
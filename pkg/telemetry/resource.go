@@ -0,0 +1,116 @@
+// Package telemetry holds the OpenTelemetry setup shared by the trace and
+// metric packages.
+package telemetry
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+type (
+	// Service identifies the application emitting telemetry.
+	Service struct {
+		Name        string
+		Version     string
+		Environment string
+	}
+
+	// ResourceConfig selects the optional resource detectors.
+	ResourceConfig struct {
+		Host       bool              `yaml:"host" env:"HOST"`
+		Process    bool              `yaml:"process" env:"PROCESS"`
+		OS         bool              `yaml:"os" env:"OS"`
+		Container  bool              `yaml:"container" env:"CONTAINER"`
+		Kubernetes bool              `yaml:"kubernetes" env:"KUBERNETES"`
+		Attributes map[string]string `yaml:"attributes" env:"ATTRIBUTES"`
+	}
+)
+
+// NewResource and NewResourceWithoutEnv build the resource describing svc.
+//
+// Attributes are applied in increasing order of precedence: SDK defaults,
+// enabled detectors, OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES (only for
+// NewResource), cfg.Attributes and finally the non-empty fields of svc.
+//
+// Detection failures of optional detectors are not fatal; the attributes that
+// could be detected are kept.
+func NewResource(ctx context.Context, svc Service, cfg ResourceConfig) (*resource.Resource, error) {
+	return newResource(ctx, svc, cfg, true)
+}
+
+func NewResourceWithoutEnv(ctx context.Context, svc Service, cfg ResourceConfig) (*resource.Resource, error) {
+	return newResource(ctx, svc, cfg, false)
+}
+
+func newResource(ctx context.Context, svc Service, cfg ResourceConfig, loadEnvs bool) (*resource.Resource, error) {
+	opts := []resource.Option{
+		resource.WithSchemaURL(semconv.SchemaURL),
+		resource.WithTelemetrySDK(),
+	}
+	if cfg.Host {
+		opts = append(opts, resource.WithHost())
+	}
+	if cfg.Process {
+		opts = append(opts,
+			resource.WithProcessPID(),
+			resource.WithProcessExecutableName(),
+			resource.WithProcessRuntimeName(),
+			resource.WithProcessRuntimeVersion(),
+			resource.WithProcessRuntimeDescription(),
+		)
+	}
+	if cfg.OS {
+		opts = append(opts, resource.WithOS())
+	}
+	if cfg.Container {
+		opts = append(opts, resource.WithDetectors(containerDetector{}))
+	}
+	if cfg.Kubernetes {
+		opts = append(opts, resource.WithDetectors(kubernetesDetector{}))
+	}
+	if loadEnvs {
+		opts = append(opts, resource.WithFromEnv())
+	}
+	opts = append(opts,
+		resource.WithAttributes(customAttributes(cfg.Attributes)...),
+		resource.WithAttributes(serviceAttributes(svc)...),
+	)
+
+	res, err := resource.New(ctx, opts...)
+	if err != nil && !errors.Is(err, resource.ErrPartialResource) {
+		return nil, err
+	}
+	if !loadEnvs {
+		return res, nil
+	}
+	return resource.Merge(resource.Default(), res)
+}
+
+func serviceAttributes(svc Service) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, 4)
+	if svc.Name != "" {
+		attrs = append(attrs, semconv.ServiceName(svc.Name))
+	}
+	if svc.Version != "" {
+		attrs = append(attrs, semconv.ServiceVersion(svc.Version))
+	}
+	if svc.Environment != "" {
+		attrs = append(attrs,
+			semconv.DeploymentEnvironmentName(svc.Environment),
+			attribute.String("env", svc.Environment),
+		)
+	}
+	return attrs
+}
+
+func customAttributes(m map[string]string) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(m))
+	for k, v := range m {
+		attrs = append(attrs, attribute.String(k, v))
+	}
+	return attrs
+}
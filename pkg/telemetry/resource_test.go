@@ -0,0 +1,76 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+func attributeValue(res *resource.Resource, key string) (string, bool) {
+	v, ok := res.Set().Value(attribute.Key(key))
+	return v.Emit(), ok
+}
+
+func TestNewResource_Service(t *testing.T) {
+	res, err := NewResource(context.Background(), Service{Name: "app", Version: "1.0.0", Environment: "prod"}, ResourceConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		"service.name":                "app",
+		"service.version":             "1.0.0",
+		"deployment.environment.name": "prod",
+		"env":                         "prod",
+		"telemetry.sdk.language":      "go",
+	}
+	for k, v := range expected {
+		if got, _ := attributeValue(res, k); got != v {
+			t.Errorf("expected %s to be %s, got %s", k, v, got)
+		}
+	}
+	if _, ok := attributeValue(res, "host.name"); ok {
+		t.Error("expected host detection to be disabled by default")
+	}
+}
+
+func TestNewResource_Precedence(t *testing.T) {
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "team=payments,region=us-east-1,service.name=from-env")
+
+	res, err := NewResource(context.Background(), Service{Name: "app"}, ResourceConfig{
+		Attributes: map[string]string{"region": "eu-west-1"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		"team":         "payments",
+		"region":       "eu-west-1",
+		"service.name": "app",
+	}
+	for k, v := range expected {
+		if got, _ := attributeValue(res, k); got != v {
+			t.Errorf("expected %s to be %s, got %s", k, v, got)
+		}
+	}
+}
+
+func TestNewResource_Detectors(t *testing.T) {
+	res, err := NewResource(context.Background(), Service{Name: "app"}, ResourceConfig{
+		Host:    true,
+		Process: true,
+		OS:      true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, key := range []string{"host.name", "process.pid", "process.runtime.name", "os.type"} {
+		if _, ok := attributeValue(res, key); !ok {
+			t.Errorf("expected attribute %s to be detected", key)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package telemetry
+
+import "testing"
+
+func TestMatchWildcard(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		value    string
+		expected bool
+	}{
+		{"/health", "/health", true},
+		{"/health", "/healthz", false},
+		{"*", "anything", true},
+		{"http.*", "http.server.duration", true},
+		{"*.duration", "http.server.duration", true},
+		{"http.*.duration", "http.client.duration", true},
+		{"http.*.duration", "db.client.duration", false},
+		{"a*c*e", "abcde", true},
+		{"a*c*e", "abde", false},
+	}
+
+	for _, tt := range tests {
+		if got := MatchWildcard(tt.pattern, tt.value); got != tt.expected {
+			t.Errorf("MatchWildcard(%q, %q) = %v, expected %v", tt.pattern, tt.value, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package trace
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/baggage"
+)
+
+// SetBaggage returns a copy of ctx with the baggage member key set to value.
+// Baggage is propagated to downstream services by the Baggage propagator.
+func SetBaggage(ctx context.Context, key string, value string) (context.Context, error) {
+	member, err := baggage.NewMemberRaw(key, value)
+	if err != nil {
+		return ctx, fmt.Errorf("invalid baggage member %s: %w", key, err)
+	}
+	b, err := baggage.FromContext(ctx).SetMember(member)
+	if err != nil {
+		return ctx, fmt.Errorf("failed to set baggage member %s: %w", key, err)
+	}
+	return baggage.ContextWithBaggage(ctx, b), nil
+}
+
+// Baggage returns all baggage members of ctx.
+func Baggage(ctx context.Context) map[string]string {
+	members := baggage.FromContext(ctx).Members()
+	result := make(map[string]string, len(members))
+	for _, m := range members {
+		result[m.Key()] = m.Value()
+	}
+	return result
+}
+
+func BaggageValue(ctx context.Context, key string) string {
+	return baggage.FromContext(ctx).Member(key).Value()
+}
+
+// BaggageAttributes returns the baggage members of ctx with the given keys as
+// attributes. Missing members are skipped.
+func BaggageAttributes(ctx context.Context, keys ...string) []Attribute {
+	b := baggage.FromContext(ctx)
+	attrs := make([]Attribute, 0, len(keys))
+	for _, key := range keys {
+		if m := b.Member(key); m.Key() != "" {
+			attrs = append(attrs, New(key, m.Value()))
+		}
+	}
+	return attrs
+}
+
+// InjectBaggage copies the baggage members with the given keys onto the current span.
+func InjectBaggage(ctx context.Context, keys ...string) {
+	if attrs := BaggageAttributes(ctx, keys...); len(attrs) > 0 {
+		InjectAttributes(ctx, attrs...)
+	}
+}
@@ -0,0 +1,75 @@
+package trace
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestSetBaggage(t *testing.T) {
+	ctx, err := SetBaggage(context.Background(), "tenant_id", "acme")
+	if err != nil {
+		t.Fatalf("failed to set baggage: %v", err)
+	}
+	ctx, err = SetBaggage(ctx, "region", "us-east-1")
+	if err != nil {
+		t.Fatalf("failed to set baggage: %v", err)
+	}
+
+	b := Baggage(ctx)
+	if len(b) != 2 || b["tenant_id"] != "acme" || b["region"] != "us-east-1" {
+		t.Errorf("unexpected baggage: %v", b)
+	}
+	if BaggageValue(ctx, "tenant_id") != "acme" {
+		t.Errorf("expected tenant_id to be acme, got %s", BaggageValue(ctx, "tenant_id"))
+	}
+	if BaggageValue(ctx, "missing") != "" {
+		t.Error("expected missing member to be empty")
+	}
+
+	if _, err := SetBaggage(ctx, "", "value"); err == nil {
+		t.Error("expected error for invalid baggage key")
+	}
+}
+
+func TestBaggagePropagation(t *testing.T) {
+	_, ctx, cleanup := setupPropagationTest(t)
+	defer cleanup()
+
+	ctx, _ = SetBaggage(ctx, "tenant_id", "acme")
+	carrier := MapCarrier(map[string]string{})
+	Inject(ctx, carrier)
+
+	extracted := Extract(context.Background(), carrier)
+	if BaggageValue(extracted, "tenant_id") != "acme" {
+		t.Errorf("expected baggage to be propagated, got %v", Baggage(extracted))
+	}
+}
+
+func TestInjectBaggage(t *testing.T) {
+	exporter, _, cleanup := setupPropagationTest(t)
+	defer cleanup()
+
+	ctx, _ := SetBaggage(context.Background(), "tenant_id", "acme")
+	defer ReplaceTracer(NewOtelTracerAdapter())()
+
+	ctx, span := otel.Tracer("test").Start(ctx, "span")
+	InjectBaggage(ctx, "tenant_id")
+	span.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	found := false
+	for _, a := range spans[0].Attributes {
+		if a == attribute.String("tenant_id", "acme") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected tenant_id attribute, got %v", spans[0].Attributes)
+	}
+}
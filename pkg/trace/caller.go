@@ -0,0 +1,43 @@
+package trace
+
+import (
+	"runtime"
+	"strings"
+)
+
+const unknownName = "unknown"
+
+// callerNames derives the trace and span names from the first function in the
+// call stack outside this package: the trace name is the package name and the
+// span name is the function name, including its receiver type.
+func callerNames() (traceName string, spanName string) {
+	var pcs [16]uintptr
+	n := runtime.Callers(2, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+
+	prefix := instrumentationName + "."
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" && !strings.HasPrefix(frame.Function, prefix) {
+			return splitFuncName(frame.Function)
+		}
+		if !more {
+			return unknownName, unknownName
+		}
+	}
+}
+
+// splitFuncName splits a fully qualified function name such as
+// "github.com/org/app/service.(*UserService).Create" into "service" and
+// "UserService.Create".
+func splitFuncName(fn string) (pkg string, name string) {
+	if idx := strings.LastIndex(fn, "/"); idx >= 0 {
+		fn = fn[idx+1:]
+	}
+	pkg, name, found := strings.Cut(fn, ".")
+	if !found {
+		return unknownName, pkg
+	}
+	name = strings.NewReplacer("(*", "", "(", "", ")", "").Replace(name)
+	return pkg, name
+}
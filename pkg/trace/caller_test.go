@@ -0,0 +1,24 @@
+package trace
+
+import "testing"
+
+func TestSplitFuncName(t *testing.T) {
+	tests := []struct {
+		fn   string
+		pkg  string
+		name string
+	}{
+		{"github.com/org/app/service.(*UserService).Create", "service", "UserService.Create"},
+		{"github.com/org/app/service.UserService.Create", "service", "UserService.Create"},
+		{"github.com/org/app/service.handle.func1", "service", "handle.func1"},
+		{"main.main", "main", "main"},
+		{"weird", unknownName, "weird"},
+	}
+
+	for _, tt := range tests {
+		pkg, name := splitFuncName(tt.fn)
+		if pkg != tt.pkg || name != tt.name {
+			t.Errorf("splitFuncName(%q) = (%q, %q), expected (%q, %q)", tt.fn, pkg, name, tt.pkg, tt.name)
+		}
+	}
+}
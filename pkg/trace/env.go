@@ -0,0 +1,120 @@
+package trace
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bruno303/go-toolkit/pkg/telemetry"
+)
+
+const (
+	envTracesExporter   = "OTEL_TRACES_EXPORTER"
+	envTracesSampler    = "OTEL_TRACES_SAMPLER"
+	envTracesSamplerArg = "OTEL_TRACES_SAMPLER_ARG"
+	envPropagators      = "OTEL_PROPAGATORS"
+	tracesSignal        = "TRACES"
+	tracesURLPath       = "/v1/traces"
+)
+
+// applyEnv fills the empty fields of c with the standard OpenTelemetry
+// environment variables.
+func (c *Config) applyEnv() error {
+	if c.ApplicationName == "" {
+		c.ApplicationName = telemetry.Getenv(telemetry.EnvServiceName)
+	}
+	if err := c.applyExporterEnv(); err != nil {
+		return err
+	}
+	if err := c.Sampler.applyEnv(); err != nil {
+		return err
+	}
+	if len(c.Propagators) == 0 {
+		if v := telemetry.Getenv(envPropagators); v != "" {
+			for _, p := range strings.Split(v, ",") {
+				c.Propagators = append(c.Propagators, Propagator(strings.TrimSpace(p)))
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Config) applyExporterEnv() error {
+	otlp, err := telemetry.LookupOTLPEnv(tracesSignal, tracesURLPath)
+	if err != nil {
+		return err
+	}
+
+	exp := &c.Exporter
+	if exp.Type == "" {
+		exporterType, err := exporterTypeFromEnv(otlp.Protocol)
+		if err != nil {
+			return err
+		}
+		exp.Type = exporterType
+	}
+
+	otlpCfg := c.OTLP()
+	otlpCfg.URLPath = exp.URLPath
+	otlpCfg.ApplyEnv(otlp)
+	c.setOTLP(otlpCfg)
+	exp.URLPath = otlpCfg.URLPath
+	return nil
+}
+
+func exporterTypeFromEnv(protocol string) (ExporterType, error) {
+	switch v := telemetry.Getenv(envTracesExporter); v {
+	case "", "otlp":
+		switch protocol {
+		case "":
+			return "", nil
+		case telemetry.ProtocolGRPC:
+			return ExporterOTLPGRPC, nil
+		case telemetry.ProtocolHTTPProtobuf:
+			return ExporterOTLPHTTP, nil
+		default:
+			return "", fmt.Errorf("unsupported OTLP protocol %q", protocol)
+		}
+	case "console":
+		return ExporterStdout, nil
+	case "none":
+		return ExporterNone, nil
+	default:
+		return "", fmt.Errorf("unsupported %s %q", envTracesExporter, v)
+	}
+}
+
+func (c *SamplerConfig) applyEnv() error {
+	if !c.isDefault() {
+		return nil
+	}
+
+	sampler := telemetry.Getenv(envTracesSampler)
+	if sampler == "" {
+		return nil
+	}
+
+	ratio := 1.0
+	if arg := telemetry.Getenv(envTracesSamplerArg); arg != "" {
+		v, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", envTracesSamplerArg, err)
+		}
+		ratio = v
+	}
+
+	name, parentBased := strings.CutPrefix(sampler, "parentbased_")
+	switch name {
+	case "always_on":
+		c.Type = SamplerAlwaysOn
+	case "always_off":
+		c.Type = SamplerAlwaysOff
+	case "traceidratio":
+		c.Type = SamplerRatio
+		c.Ratio = ratio
+	default:
+		return fmt.Errorf("unsupported %s %q", envTracesSampler, sampler)
+	}
+	c.ParentBased = parentBased
+	return nil
+}
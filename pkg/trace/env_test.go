@@ -0,0 +1,140 @@
+package trace
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConfig_ApplyEnv(t *testing.T) {
+	t.Setenv("OTEL_SERVICE_NAME", "from-env")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "https://collector:4318/custom")
+	t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", "http/protobuf")
+	t.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "authorization=Bearer%20token")
+	t.Setenv("OTEL_EXPORTER_OTLP_TIMEOUT", "1000")
+	t.Setenv("OTEL_TRACES_SAMPLER", "parentbased_traceidratio")
+	t.Setenv("OTEL_TRACES_SAMPLER_ARG", "0.2")
+	t.Setenv("OTEL_PROPAGATORS", "tracecontext, b3")
+
+	cfg := Config{}
+	if err := cfg.applyEnv(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.ApplicationName != "from-env" {
+		t.Errorf("expected application name from env, got %s", cfg.ApplicationName)
+	}
+	if cfg.Endpoint != "collector:4318" || !cfg.Exporter.TLS.Enabled {
+		t.Errorf("unexpected endpoint %s (tls: %v)", cfg.Endpoint, cfg.Exporter.TLS.Enabled)
+	}
+	if cfg.Exporter.Type != ExporterOTLPHTTP {
+		t.Errorf("expected otlp http exporter, got %s", cfg.Exporter.Type)
+	}
+	if cfg.Exporter.URLPath != "/custom/v1/traces" {
+		t.Errorf("expected the traces URL path, got %s", cfg.Exporter.URLPath)
+	}
+	if cfg.Exporter.Headers["authorization"] != "Bearer token" {
+		t.Errorf("unexpected headers %v", cfg.Exporter.Headers)
+	}
+	if cfg.Exporter.Timeout != time.Second {
+		t.Errorf("expected timeout 1s, got %v", cfg.Exporter.Timeout)
+	}
+	if cfg.Sampler.Type != SamplerRatio || cfg.Sampler.Ratio != 0.2 || !cfg.Sampler.ParentBased {
+		t.Errorf("unexpected sampler %+v", cfg.Sampler)
+	}
+	if len(cfg.Propagators) != 2 || cfg.Propagators[1] != PropagatorB3 {
+		t.Errorf("unexpected propagators %v", cfg.Propagators)
+	}
+}
+
+func TestConfig_ApplyEnv_Exporter(t *testing.T) {
+	tests := []struct {
+		exporter string
+		protocol string
+		expected ExporterType
+	}{
+		{"", "", ""},
+		{"otlp", "grpc", ExporterOTLPGRPC},
+		{"otlp", "http/protobuf", ExporterOTLPHTTP},
+		{"console", "", ExporterStdout},
+		{"none", "", ExporterNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.exporter+" "+tt.protocol, func(t *testing.T) {
+			t.Setenv("OTEL_TRACES_EXPORTER", tt.exporter)
+			t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", tt.protocol)
+			cfg := Config{}
+			if err := cfg.applyEnv(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cfg.Exporter.Type != tt.expected {
+				t.Errorf("expected exporter %q, got %q", tt.expected, cfg.Exporter.Type)
+			}
+		})
+	}
+}
+
+func TestConfig_ApplyEnv_ConfigTakesPrecedence(t *testing.T) {
+	t.Setenv("OTEL_SERVICE_NAME", "from-env")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://collector:4317")
+	t.Setenv("OTEL_TRACES_SAMPLER", "always_off")
+	t.Setenv("OTEL_TRACES_EXPORTER", "console")
+
+	cfg := Config{
+		ApplicationName: "app",
+		Endpoint:        "localhost:4317",
+		Exporter:        ExporterConfig{Type: ExporterOTLPGRPC},
+		Sampler:         SamplerConfig{Type: SamplerRatio, Ratio: 0.5},
+	}
+	if err := cfg.applyEnv(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.ApplicationName != "app" || cfg.Endpoint != "localhost:4317" {
+		t.Errorf("expected config values to be kept, got %s %s", cfg.ApplicationName, cfg.Endpoint)
+	}
+	if cfg.Exporter.Type != ExporterOTLPGRPC {
+		t.Errorf("expected exporter to be kept, got %s", cfg.Exporter.Type)
+	}
+	if cfg.Sampler.Type != SamplerRatio {
+		t.Errorf("expected sampler to be kept, got %s", cfg.Sampler.Type)
+	}
+}
+
+func TestConfig_ApplyEnv_Errors(t *testing.T) {
+	tests := []struct {
+		key   string
+		value string
+	}{
+		{"OTEL_TRACES_SAMPLER", "jaeger_remote"},
+		{"OTEL_TRACES_SAMPLER_ARG", "half"},
+		{"OTEL_TRACES_EXPORTER", "zipkin"},
+		{"OTEL_EXPORTER_OTLP_PROTOCOL", "http/json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			t.Setenv("OTEL_TRACES_SAMPLER", "traceidratio")
+			t.Setenv(tt.key, tt.value)
+			cfg := Config{}
+			if err := cfg.applyEnv(); err == nil {
+				t.Errorf("expected error for %s=%s", tt.key, tt.value)
+			}
+		})
+	}
+}
+
+func TestSetupOTelSDK_DisableEnv(t *testing.T) {
+	t.Setenv("OTEL_TRACES_EXPORTER", "zipkin")
+
+	exporter := NewInMemoryExporter()
+	shutdown, err := SetupOTelSDK(t.Context(), Config{
+		ApplicationName: "app",
+		Exporter:        ExporterConfig{Type: ExporterInMemory, InMemory: exporter},
+		DisableEnv:      true,
+	})
+	if err != nil {
+		t.Fatalf("expected env to be ignored, got %v", err)
+	}
+	shutdown(t.Context())
+}
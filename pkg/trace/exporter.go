@@ -0,0 +1,164 @@
+package trace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/bruno303/go-toolkit/pkg/telemetry"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc/credentials"
+)
+
+type (
+	ExporterType string
+
+	ExporterConfig struct {
+		// Type selects the exporter. Defaults to ExporterOTLPGRPC.
+		Type ExporterType `yaml:"type" env:"TYPE"`
+		// Headers are sent with every OTLP export request (e.g. authorization).
+		Headers map[string]string `yaml:"headers" env:"HEADERS"`
+		// Compression is the OTLP payload compression, "gzip" or "none".
+		Compression string `yaml:"compression" env:"COMPRESSION"`
+		// Timeout bounds each OTLP export request. Zero uses the exporter default.
+		Timeout time.Duration `yaml:"timeout" env:"TIMEOUT"`
+		// URLPath overrides the OTLP HTTP path (default "/v1/traces").
+		URLPath string `yaml:"url_path" env:"URL_PATH"`
+		// TLS enables a secure connection to the OTLP endpoint.
+		TLS TLSConfig `yaml:"tls" env:", prefix=TLS_"`
+		// PrettyPrint indents the stdout exporter output.
+		PrettyPrint bool `yaml:"pretty_print" env:"PRETTY_PRINT"`
+		// Writer is the stdout exporter destination. Defaults to os.Stdout.
+		Writer io.Writer `yaml:"-"`
+		// InMemory receives the spans when Type is ExporterInMemory.
+		InMemory *InMemoryExporter `yaml:"-" env:",noinit"`
+	}
+
+	TLSConfig = telemetry.TLSConfig
+
+	InMemoryExporter = tracetest.InMemoryExporter
+)
+
+const (
+	ExporterOTLPGRPC ExporterType = "otlp-grpc"
+	ExporterOTLPHTTP ExporterType = "otlp-http"
+	ExporterStdout   ExporterType = "stdout"
+	ExporterInMemory ExporterType = "memory"
+	// ExporterNone exports nothing. The spans still reach the processors of
+	// Config.SpanProcessors.
+	ExporterNone ExporterType = "none"
+)
+
+// NewInMemoryExporter returns an exporter that keeps finished spans in memory,
+// meant to be used with ExporterInMemory in tests.
+func NewInMemoryExporter() *InMemoryExporter {
+	return tracetest.NewInMemoryExporter()
+}
+
+// synchronous reports whether spans should be exported as soon as they end
+// instead of being batched.
+func (c ExporterConfig) synchronous() bool {
+	return c.Type == ExporterStdout || c.Type == ExporterInMemory
+}
+
+// newExporter returns the exporter of cfg, nil for ExporterNone.
+func newExporter(ctx context.Context, cfg Config) (sdktrace.SpanExporter, error) {
+	switch cfg.Exporter.Type {
+	case "", ExporterOTLPGRPC:
+		return newGRPCExporter(ctx, cfg)
+	case ExporterOTLPHTTP:
+		return newHTTPExporter(ctx, cfg)
+	case ExporterStdout:
+		return newStdoutExporter(cfg.Exporter)
+	case ExporterInMemory:
+		if cfg.Exporter.InMemory == nil {
+			return nil, errors.New("Exporter.InMemory must be informed for the in-memory exporter")
+		}
+		return cfg.Exporter.InMemory, nil
+	case ExporterNone:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown trace exporter type %q", cfg.Exporter.Type)
+	}
+}
+
+func newGRPCExporter(ctx context.Context, cfg Config) (sdktrace.SpanExporter, error) {
+	exp := cfg.Exporter
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+
+	if exp.TLS.Enabled {
+		tlsCfg, err := exp.TLS.Build()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg)))
+	} else {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	if len(exp.Headers) > 0 {
+		opts = append(opts, otlptracegrpc.WithHeaders(exp.Headers))
+	}
+	gzip, err := telemetry.UseGzip(exp.Compression)
+	if err != nil {
+		return nil, err
+	}
+	if gzip {
+		opts = append(opts, otlptracegrpc.WithCompressor(telemetry.CompressionGzip))
+	}
+	if exp.Timeout > 0 {
+		opts = append(opts, otlptracegrpc.WithTimeout(exp.Timeout))
+	}
+
+	return otlptrace.New(ctx, otlptracegrpc.NewClient(opts...))
+}
+
+func newHTTPExporter(ctx context.Context, cfg Config) (sdktrace.SpanExporter, error) {
+	exp := cfg.Exporter
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint)}
+
+	if exp.TLS.Enabled {
+		tlsCfg, err := exp.TLS.Build()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, otlptracehttp.WithTLSClientConfig(tlsCfg))
+	} else {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	if len(exp.Headers) > 0 {
+		opts = append(opts, otlptracehttp.WithHeaders(exp.Headers))
+	}
+	gzip, err := telemetry.UseGzip(exp.Compression)
+	if err != nil {
+		return nil, err
+	}
+	if gzip {
+		opts = append(opts, otlptracehttp.WithCompression(otlptracehttp.GzipCompression))
+	}
+	if exp.Timeout > 0 {
+		opts = append(opts, otlptracehttp.WithTimeout(exp.Timeout))
+	}
+	if exp.URLPath != "" {
+		opts = append(opts, otlptracehttp.WithURLPath(exp.URLPath))
+	}
+
+	return otlptrace.New(ctx, otlptracehttp.NewClient(opts...))
+}
+
+func newStdoutExporter(exp ExporterConfig) (sdktrace.SpanExporter, error) {
+	opts := make([]stdouttrace.Option, 0, 2)
+	if exp.Writer != nil {
+		opts = append(opts, stdouttrace.WithWriter(exp.Writer))
+	}
+	if exp.PrettyPrint {
+		opts = append(opts, stdouttrace.WithPrettyPrint())
+	}
+	return stdouttrace.New(opts...)
+}
@@ -0,0 +1,112 @@
+package trace
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSetupOTelSDK_InMemoryExporter(t *testing.T) {
+	ctx := context.Background()
+	exporter := NewInMemoryExporter()
+
+	shutdown, err := SetupOTelSDK(ctx, Config{
+		ApplicationName: "test-app",
+		Exporter:        ExporterConfig{Type: ExporterInMemory, InMemory: exporter},
+	})
+	if err != nil {
+		t.Fatalf("failed to setup sdk: %v", err)
+	}
+	defer shutdown(ctx)
+
+	_, err = NewOtelTracerAdapter().Trace(ctx, NameConfig("test", "span"), func(ctx context.Context) (any, error) {
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	if spans[0].Name != "test.span" {
+		t.Errorf("expected span name 'test.span', got %s", spans[0].Name)
+	}
+}
+
+func TestSetupOTelSDK_StdoutExporter(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+
+	shutdown, err := SetupOTelSDK(ctx, Config{
+		ApplicationName: "test-app",
+		Exporter:        ExporterConfig{Type: ExporterStdout, Writer: &buf},
+	})
+	if err != nil {
+		t.Fatalf("failed to setup sdk: %v", err)
+	}
+	defer shutdown(ctx)
+
+	_, _ = NewOtelTracerAdapter().Trace(ctx, NameConfig("stdout", "span"), func(ctx context.Context) (any, error) {
+		return nil, nil
+	})
+
+	if !strings.Contains(buf.String(), "stdout.span") {
+		t.Errorf("expected stdout output to contain span name, got %s", buf.String())
+	}
+}
+
+func TestSetupOTelSDK_NoneExporter(t *testing.T) {
+	ctx := context.Background()
+	shutdown, err := SetupOTelSDK(ctx, Config{ApplicationName: "test-app", Exporter: ExporterConfig{Type: ExporterNone}})
+	if err != nil {
+		t.Fatalf("failed to setup sdk: %v", err)
+	}
+	if err := shutdown(ctx); err != nil {
+		t.Errorf("unexpected shutdown error: %v", err)
+	}
+}
+
+func TestSetupOTelSDK_ExporterErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  ExporterConfig
+	}{
+		{"unknown type", ExporterConfig{Type: "zipkin"}},
+		{"in-memory without exporter", ExporterConfig{Type: ExporterInMemory}},
+		{"missing CA file", ExporterConfig{Type: ExporterOTLPHTTP, TLS: TLSConfig{Enabled: true, CAFile: "testdata/missing.pem"}}},
+		{"unknown gRPC compression", ExporterConfig{Compression: "zstd"}},
+		{"unknown HTTP compression", ExporterConfig{Type: ExporterOTLPHTTP, Compression: "zstd"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := SetupOTelSDK(context.Background(), Config{ApplicationName: "test-app", Exporter: tt.cfg})
+			if err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestConfig_OTLP(t *testing.T) {
+	cfg := Config{
+		Endpoint: "collector:4317",
+		Exporter: ExporterConfig{
+			Headers:     map[string]string{"api-key": "secret"},
+			Compression: "gzip",
+			URLPath:     "/custom/v1/traces",
+			TLS:         TLSConfig{Enabled: true},
+		},
+	}
+
+	otlp := cfg.OTLP()
+	if otlp.Endpoint != "collector:4317" || otlp.Headers["api-key"] != "secret" || otlp.Compression != "gzip" || !otlp.TLS.Enabled {
+		t.Errorf("unexpected OTLP config %+v", otlp)
+	}
+	if otlp.URLPath != "" {
+		t.Errorf("expected the traces URL path to be left out, got %s", otlp.URLPath)
+	}
+}
@@ -0,0 +1,162 @@
+package trace
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+
+	"go.opentelemetry.io/otel/codes"
+	tracelib "go.opentelemetry.io/otel/trace"
+)
+
+type (
+	// PanicError is returned, and recorded on the span, when a function
+	// started with Go or Group.Go panics.
+	PanicError struct {
+		Value any
+		Stack []byte
+	}
+
+	// strictTracer is implemented by the tracers of the package, which
+	// reject missing names when strict.
+	strictTracer interface {
+		isStrict() bool
+	}
+
+	// Group runs functions in goroutines, each one in a child span of the
+	// context the group was created with, linked to it, and waits for them
+	// like errgroup.
+	// It must be created with NewGroup.
+	Group struct {
+		ctx    context.Context
+		cancel context.CancelCauseFunc
+		wg     sync.WaitGroup
+		sem    chan struct{}
+
+		errOnce sync.Once
+		err     error
+	}
+)
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Go runs fn in a new goroutine within a child span of the span in ctx, also
+// linked to it, whose status is Error when fn fails or panics. The
+// context keeps the values of ctx but not its cancellation, so the work is not
+// interrupted when the caller returns. Panics are recovered and reported as a
+// *PanicError. The returned function waits for fn and returns its error; it
+// may be called more than once.
+func Go(ctx context.Context, cfg *TraceConfig, fn func(ctx context.Context) error) (wait func() error) {
+	resolved := goroutineConfig(cfg)
+	ctx = context.WithoutCancel(ctx)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- runTraced(ctx, resolved, fn)
+	}()
+	return sync.OnceValue(func() error {
+		return <-done
+	})
+}
+
+// NewGroup returns a Group and a context derived from ctx that is cancelled
+// when a function of the group fails or when Wait returns.
+func NewGroup(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	return &Group{ctx: ctx, cancel: cancel}, ctx
+}
+
+// SetLimit limits the number of functions running at once. Go blocks while
+// the limit is reached. A negative value removes the limit. It must not be
+// called while functions are running.
+func (g *Group) SetLimit(n int) {
+	if n < 0 {
+		g.sem = nil
+		return
+	}
+	g.sem = make(chan struct{}, n)
+}
+
+// Go runs fn in a new goroutine within a child span of the group context.
+// The first error, including a recovered *PanicError, cancels the group
+// context and is returned by Wait.
+func (g *Group) Go(cfg *TraceConfig, fn func(ctx context.Context) error) {
+	resolved := goroutineConfig(cfg)
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+
+	g.wg.Add(1)
+	go func() {
+		defer g.done()
+		if err := runTraced(g.ctx, resolved, fn); err != nil {
+			g.errOnce.Do(func() {
+				g.err = err
+				g.cancel(err)
+			})
+		}
+	}()
+}
+
+// Wait blocks until all the functions of the group return and returns the
+// first error.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.cancel(g.err)
+	return g.err
+}
+
+func (g *Group) done() {
+	if g.sem != nil {
+		<-g.sem
+	}
+	g.wg.Done()
+}
+
+// goroutineConfig copies cfg, deriving missing names from the caller while it
+// is still on the stack, before the goroutine starts. Names are left missing
+// for a strict global tracer, which then returns ErrInvalidTraceConfig.
+func goroutineConfig(cfg *TraceConfig) *TraceConfig {
+	if cfg == nil {
+		cfg = DefaultTraceCfg()
+	}
+	resolved := *cfg
+	if t, ok := GetTracer().(strictTracer); ok && t.isStrict() {
+		return &resolved
+	}
+	if resolved.TraceName == "" || resolved.SpanName == "" {
+		traceName, spanName := callerNames()
+		if resolved.TraceName == "" {
+			resolved.TraceName = traceName
+		}
+		if resolved.SpanName == "" {
+			resolved.SpanName = spanName
+		}
+	}
+	return &resolved
+}
+
+// runTraced runs fn in a span linked to the spawning span of ctx. Errors and
+// recovered panics set the span status to Error.
+func runTraced(ctx context.Context, cfg *TraceConfig, fn func(ctx context.Context) error) error {
+	spawner := tracelib.SpanContextFromContext(ctx)
+	_, err := Trace(ctx, cfg, func(ctx context.Context) (_ any, err error) {
+		span := tracelib.SpanFromContext(ctx)
+		if spawner.IsValid() {
+			span.AddLink(tracelib.Link{SpanContext: spawner})
+		}
+		defer func() {
+			if r := recover(); r != nil {
+				err = &PanicError{Value: r, Stack: debug.Stack()}
+			}
+			if err != nil {
+				span.SetStatus(codes.Error, err.Error())
+			}
+		}()
+		return nil, fn(ctx)
+	})
+	return err
+}
@@ -0,0 +1,161 @@
+package trace_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/bruno303/go-toolkit/pkg/trace"
+	"github.com/bruno303/go-toolkit/pkg/trace/tracetest"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+type ctxKey struct{}
+
+func TestGo_OutlivesParentSpan(t *testing.T) {
+	rec := tracetest.NewRecorder(t)
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey{}, "value"))
+
+	var wait func() error
+	release := make(chan struct{})
+	_, _ = trace.Trace(ctx, trace.NameConfig("handler", "serve"), func(ctx context.Context) (any, error) {
+		wait = trace.Go(ctx, trace.NameConfig("worker", "process"), func(ctx context.Context) error {
+			<-release
+			if ctx.Value(ctxKey{}) != "value" {
+				t.Error("expected context values to be propagated")
+			}
+			return ctx.Err()
+		})
+		return nil, nil
+	})
+	cancel()
+	close(release)
+
+	if err := wait(); err != nil {
+		t.Errorf("expected worker not to be cancelled with the parent, got %v", err)
+	}
+	if err := wait(); err != nil {
+		t.Errorf("expected wait to be repeatable, got %v", err)
+	}
+
+	tracetest.AssertSpanNames(t, rec, "handler.serve", "worker.process")
+	worker := tracetest.AssertSpan(t, rec, "worker.process")
+	handler := tracetest.AssertSpan(t, rec, "handler.serve")
+	tracetest.AssertParent(t, worker, handler)
+	tracetest.AssertStatus(t, worker, codes.Unset)
+	if links := worker.Links(); len(links) != 1 || links[0].SpanContext.SpanID() != handler.SpanContext().SpanID() {
+		t.Errorf("expected a link to the spawning span, got %+v", links)
+	}
+}
+
+func TestGo_RecoversPanic(t *testing.T) {
+	rec := tracetest.NewRecorder(t)
+
+	err := trace.Go(context.Background(), nil, func(ctx context.Context) error {
+		panic("boom")
+	})()
+
+	var panicErr *trace.PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("expected a PanicError, got %v", err)
+	}
+	if panicErr.Value != "boom" || len(panicErr.Stack) == 0 {
+		t.Errorf("unexpected panic error: %+v", panicErr)
+	}
+
+	span := tracetest.AssertSpan(t, rec, "trace_test.TestGo_RecoversPanic")
+	tracetest.AssertError(t, span)
+	tracetest.AssertStatus(t, span, codes.Error)
+	if len(span.Links()) != 0 {
+		t.Errorf("expected no link without a spawning span, got %+v", span.Links())
+	}
+}
+
+func TestGroup_WaitsAndReturnsFirstError(t *testing.T) {
+	rec := tracetest.NewRecorder(t)
+	expected := errors.New("failed")
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+	g, gctx := trace.NewGroup(ctx)
+	g.Go(trace.NameConfig("worker", "fail"), func(ctx context.Context) error {
+		return expected
+	})
+	g.Go(trace.NameConfig("worker", "wait"), func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	})
+
+	if err := g.Wait(); !errors.Is(err, expected) {
+		t.Errorf("expected %v, got %v", expected, err)
+	}
+	if !errors.Is(context.Cause(gctx), expected) {
+		t.Errorf("expected group context to be cancelled with %v, got %v", expected, context.Cause(gctx))
+	}
+	parent.End()
+
+	parentSpan := tracetest.AssertSpan(t, rec, "parent")
+	for _, name := range []string{"worker.fail", "worker.wait"} {
+		tracetest.AssertParent(t, tracetest.AssertSpan(t, rec, name), parentSpan)
+	}
+	tracetest.AssertError(t, tracetest.AssertSpan(t, rec, "worker.fail"))
+	tracetest.AssertStatus(t, tracetest.AssertSpan(t, rec, "worker.fail"), codes.Error)
+	tracetest.AssertStatus(t, tracetest.AssertSpan(t, rec, "worker.wait"), codes.Unset)
+}
+
+func TestGroup_SetLimit(t *testing.T) {
+	tracetest.NewRecorder(t)
+
+	g, ctx := trace.NewGroup(context.Background())
+	g.SetLimit(2)
+
+	var running, maxRunning atomic.Int32
+	for range 10 {
+		g.Go(trace.NameConfig("worker", "limited"), func(ctx context.Context) error {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				current := maxRunning.Load()
+				if n <= current || maxRunning.CompareAndSwap(current, n) {
+					break
+				}
+			}
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if maxRunning.Load() > 2 {
+		t.Errorf("expected at most 2 goroutines running, got %d", maxRunning.Load())
+	}
+	if ctx.Err() == nil {
+		t.Error("expected group context to be cancelled after Wait")
+	}
+}
+
+func TestGo_StrictTracer(t *testing.T) {
+	restore := trace.ReplaceTracer(trace.NewOtelTracerAdapterWithOpts(trace.OtelTracerAdapterOpts{Strict: true}))
+	defer restore()
+
+	called := false
+	fn := func(ctx context.Context) error {
+		called = true
+		return nil
+	}
+
+	if err := trace.Go(context.Background(), nil, fn)(); !errors.Is(err, trace.ErrInvalidTraceConfig) {
+		t.Errorf("expected ErrInvalidTraceConfig from Go, got %v", err)
+	}
+
+	group, _ := trace.NewGroup(context.Background())
+	group.Go(&trace.TraceConfig{TraceName: "worker"}, fn)
+	if err := group.Wait(); !errors.Is(err, trace.ErrInvalidTraceConfig) {
+		t.Errorf("expected ErrInvalidTraceConfig from Group.Go, got %v", err)
+	}
+	if called {
+		t.Error("expected the functions not to be called")
+	}
+}
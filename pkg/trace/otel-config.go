@@ -5,21 +5,28 @@ import (
 	"errors"
 	"time"
 
+	"github.com/bruno303/go-toolkit/pkg/telemetry"
 	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
-	"go.opentelemetry.io/otel/propagation"
-	"go.opentelemetry.io/otel/sdk/resource"
 	"go.opentelemetry.io/otel/sdk/trace"
-	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
 )
 
 type Config struct {
-	Endpoint           string
-	ApplicationName    string
-	ApplicationVersion string
-	Environment        string
+	Endpoint           string                   `yaml:"endpoint" env:"ENDPOINT"`
+	ApplicationName    string                   `yaml:"application_name" env:"APPLICATION_NAME"`
+	ApplicationVersion string                   `yaml:"application_version" env:"APPLICATION_VERSION"`
+	Environment        string                   `yaml:"environment" env:"ENVIRONMENT"`
+	Exporter           ExporterConfig           `yaml:"exporter" env:", prefix=EXPORTER_"`
+	Sampler            SamplerConfig            `yaml:"sampler" env:", prefix=SAMPLER_"`
+	Propagators        []Propagator             `yaml:"propagators" env:"PROPAGATORS"`
+	Resource           telemetry.ResourceConfig `yaml:"resource" env:", prefix=RESOURCE_"`
+	Processors         ProcessorsConfig         `yaml:"processors" env:", prefix=PROCESSORS_"`
+	// SpanProcessors are registered after the built-in enrichment and before
+	// the exporter. Use NewDropProcessor or NewRedactProcessor to wrap a processor
+	// that should only receive part of the spans.
+	SpanProcessors []trace.SpanProcessor `yaml:"-"`
+	// DisableEnv stops the empty fields from being filled with the standard
+	// OTEL_* environment variables.
+	DisableEnv bool `yaml:"disable_env" env:"DISABLE_ENV"`
 }
 
 func SetupOTelSDK(ctx context.Context, cfg Config) (shutdown func(context.Context) error, err error) {
@@ -36,11 +43,23 @@ func SetupOTelSDK(ctx context.Context, cfg Config) (shutdown func(context.Contex
 		err = errors.Join(inErr, shutdown(ctx))
 	}
 
+	if !cfg.DisableEnv {
+		if err = cfg.applyEnv(); err != nil {
+			handleErr(err)
+			return
+		}
+	}
+
 	// Set up propagator.
-	otel.SetTextMapPropagator(newPropagator())
+	propagator, err := newPropagator(cfg.Propagators)
+	if err != nil {
+		handleErr(err)
+		return
+	}
+	otel.SetTextMapPropagator(propagator)
 
 	// Set up trace provider.
-	tracerProvider, err := newTraceProvider(cfg)
+	tracerProvider, err := newTraceProvider(ctx, cfg)
 	if err != nil {
 		handleErr(err)
 		return
@@ -51,43 +70,71 @@ func SetupOTelSDK(ctx context.Context, cfg Config) (shutdown func(context.Contex
 	return
 }
 
-func newPropagator() propagation.TextMapPropagator {
-	return propagation.NewCompositeTextMapPropagator(
-		propagation.TraceContext{},
-		propagation.Baggage{},
-	)
+func (c Config) service() telemetry.Service {
+	return telemetry.Service{
+		Name:        c.ApplicationName,
+		Version:     c.ApplicationVersion,
+		Environment: c.Environment,
+	}
+}
+
+// OTLP returns the OTLP connection settings of c, e.g. to export metrics to
+// the same collector. The URL path is left out, as it is specific to traces.
+func (c Config) OTLP() telemetry.OTLPConfig {
+	return telemetry.OTLPConfig{
+		Endpoint:    c.Endpoint,
+		Headers:     c.Exporter.Headers,
+		Compression: c.Exporter.Compression,
+		Timeout:     c.Exporter.Timeout,
+		TLS:         c.Exporter.TLS,
+	}
 }
 
-func newTraceProvider(cfg Config) (*trace.TracerProvider, error) {
-	res, err := resource.Merge(
-		resource.Default(),
-		resource.NewWithAttributes(
-			semconv.SchemaURL,
-			semconv.ServiceName(cfg.ApplicationName),
-			semconv.ServiceVersion(cfg.ApplicationVersion),
-			semconv.DeploymentEnvironmentName(cfg.Environment),
-			attribute.String("env", cfg.Environment),
-		),
-	)
+// setOTLP sets the connection settings of c, all but the URL path.
+func (c *Config) setOTLP(otlp telemetry.OTLPConfig) {
+	c.Endpoint = otlp.Endpoint
+	c.Exporter.Headers = otlp.Headers
+	c.Exporter.Compression = otlp.Compression
+	c.Exporter.Timeout = otlp.Timeout
+	c.Exporter.TLS = otlp.TLS
+}
 
+func newTraceProvider(ctx context.Context, cfg Config) (*trace.TracerProvider, error) {
+	newResource := telemetry.NewResource
+	if cfg.DisableEnv {
+		newResource = telemetry.NewResourceWithoutEnv
+	}
+	res, err := newResource(ctx, cfg.service(), cfg.Resource)
 	if err != nil {
 		return nil, err
 	}
 
-	traceExporter, err := otlptrace.New(
-		context.Background(),
-		otlptracegrpc.NewClient(
-			otlptracegrpc.WithInsecure(),
-			otlptracegrpc.WithEndpoint(cfg.Endpoint),
-		),
-	)
+	traceExporter, err := newExporter(ctx, cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	traceProvider := trace.NewTracerProvider(
-		trace.WithBatcher(traceExporter, trace.WithBatchTimeout(5*time.Second)),
-		trace.WithResource(res),
-	)
-	return traceProvider, nil
+	opts := []trace.TracerProviderOption{trace.WithResource(res)}
+	if enrich := cfg.Processors.enrichProcessor(); enrich != nil {
+		opts = append(opts, trace.WithSpanProcessor(enrich))
+	}
+	for _, p := range cfg.SpanProcessors {
+		opts = append(opts, trace.WithSpanProcessor(cfg.Processors.wrap(p)))
+	}
+	if traceExporter != nil {
+		exportProcessor := trace.NewBatchSpanProcessor(traceExporter, trace.WithBatchTimeout(5*time.Second))
+		if cfg.Exporter.synchronous() {
+			exportProcessor = trace.NewSimpleSpanProcessor(traceExporter)
+		}
+		opts = append(opts, trace.WithSpanProcessor(cfg.Processors.wrap(exportProcessor)))
+	}
+	if !cfg.Sampler.isDefault() {
+		sampler, err := newSampler(cfg.Sampler)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, trace.WithSampler(sampler))
+	}
+
+	return trace.NewTracerProvider(opts...), nil
 }
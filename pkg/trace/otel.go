@@ -11,19 +11,32 @@ import (
 	tracelib "go.opentelemetry.io/otel/trace"
 )
 
-type OtelTracerAdapter struct{}
+type (
+	OtelTracerAdapter struct {
+		strict bool
+	}
+	OtelTracerAdapterOpts struct {
+		// Strict makes Trace return ErrInvalidTraceConfig, without calling the
+		// callback, when names are missing instead of deriving them from the caller.
+		Strict bool
+	}
+)
 
 func NewOtelTracerAdapter() OtelTracerAdapter {
 	return OtelTracerAdapter{}
 }
 
+func NewOtelTracerAdapterWithOpts(opts OtelTracerAdapterOpts) OtelTracerAdapter {
+	return OtelTracerAdapter{strict: opts.Strict}
+}
+
 func (t OtelTracerAdapter) Trace(ctx context.Context, cfg *TraceConfig, cb TraceCallback) (any, error) {
-	if cfg == nil {
-		cfg = DefaultTraceCfg()
+	resolved, err := t.resolveConfig(cfg)
+	if err != nil {
+		return nil, err
 	}
-	cfg.Validate()
 
-	ctx, span := startSpan(ctx, cfg.TraceName, cfg.SpanName)
+	ctx, span := startSpan(ctx, resolved.TraceName, resolved.SpanName)
 	defer span.End()
 	res, err := cb(ctx)
 	if err != nil {
@@ -60,6 +73,34 @@ func (t OtelTracerAdapter) InjectError(ctx context.Context, err error) {
 	span.RecordError(err)
 }
 
+func (t OtelTracerAdapter) isStrict() bool {
+	return t.strict
+}
+
+// resolveConfig returns a validated copy of cfg. Missing names are derived
+// from the calling function unless the adapter is strict.
+func (t OtelTracerAdapter) resolveConfig(cfg *TraceConfig) (TraceConfig, error) {
+	if cfg == nil {
+		cfg = DefaultTraceCfg()
+	}
+	resolved := *cfg
+
+	if !t.strict && (resolved.TraceName == "" || resolved.SpanName == "") {
+		traceName, spanName := callerNames()
+		if resolved.TraceName == "" {
+			resolved.TraceName = traceName
+		}
+		if resolved.SpanName == "" {
+			resolved.SpanName = spanName
+		}
+	}
+
+	if err := resolved.Validate(); err != nil {
+		return resolved, fmt.Errorf("%w: %w", ErrInvalidTraceConfig, err)
+	}
+	return resolved, nil
+}
+
 func startSpan(ctx context.Context, tracerName string, spanName string) (context.Context, tracelib.Span) {
 	return otel.Tracer(tracerName).Start(
 		ctx,
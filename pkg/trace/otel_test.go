@@ -0,0 +1,71 @@
+package trace_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bruno303/go-toolkit/pkg/trace"
+	"github.com/bruno303/go-toolkit/pkg/trace/tracetest"
+)
+
+type userService struct{}
+
+func (s *userService) create(ctx context.Context) {
+	_, _ = trace.Trace(ctx, nil, func(ctx context.Context) (any, error) {
+		return nil, nil
+	})
+}
+
+func TestOtelTracerAdapter_DerivesNames(t *testing.T) {
+	rec := tracetest.NewRecorder(t)
+	ctx := context.Background()
+
+	(&userService{}).create(ctx)
+	_, _ = trace.Trace(ctx, &trace.TraceConfig{TraceName: "custom"}, func(ctx context.Context) (any, error) {
+		return nil, nil
+	})
+
+	tracetest.AssertSpanNames(t, rec,
+		"trace_test.userService.create",
+		"custom.TestOtelTracerAdapter_DerivesNames",
+	)
+}
+
+func TestOtelTracerAdapter_DoesNotMutateConfig(t *testing.T) {
+	tracetest.NewRecorder(t)
+	cfg := trace.DefaultTraceCfg()
+
+	_, _ = trace.Trace(context.Background(), cfg, func(ctx context.Context) (any, error) {
+		return nil, nil
+	})
+
+	if cfg.TraceName != "" || cfg.SpanName != "" {
+		t.Errorf("expected config to be left untouched, got %+v", cfg)
+	}
+}
+
+func TestOtelTracerAdapter_Strict(t *testing.T) {
+	rec := tracetest.NewRecorder(t)
+	tracer := trace.NewOtelTracerAdapterWithOpts(trace.OtelTracerAdapterOpts{Strict: true})
+
+	called := false
+	_, err := tracer.Trace(context.Background(), nil, func(ctx context.Context) (any, error) {
+		called = true
+		return nil, nil
+	})
+	if !errors.Is(err, trace.ErrInvalidTraceConfig) {
+		t.Errorf("expected ErrInvalidTraceConfig, got %v", err)
+	}
+	if called {
+		t.Error("expected callback to not be called")
+	}
+
+	_, err = tracer.Trace(context.Background(), trace.NameConfig("strict", "span"), func(ctx context.Context) (any, error) {
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tracetest.AssertSpanNames(t, rec, "strict.span")
+}
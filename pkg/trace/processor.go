@@ -0,0 +1,234 @@
+package trace
+
+import (
+	"context"
+	"strings"
+
+	"github.com/bruno303/go-toolkit/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+type (
+	// ProcessorsConfig configures the built-in span processors, applied in this
+	// order before the spans reach Config.SpanProcessors and the exporter:
+	// enrichment, dropping and redaction. Dropping and redaction apply when
+	// the spans end, so OnStart of the processors still sees every span.
+	ProcessorsConfig struct {
+		// Attributes are added to every span.
+		Attributes map[string]string `yaml:"attributes" env:"ATTRIBUTES"`
+		// BaggageKeys are copied from the baggage of the parent context to every span.
+		BaggageKeys []string `yaml:"baggage_keys" env:"BAGGAGE_KEYS"`
+		// DropSpanNames drops the spans with matching names. '*' is a wildcard.
+		DropSpanNames []string `yaml:"drop_span_names" env:"DROP_SPAN_NAMES"`
+		// RedactKeys replaces the value of matching attributes (case insensitive,
+		// '*' is a wildcard), of the spans and of their events, with
+		// RedactedValue.
+		RedactKeys []string `yaml:"redact_keys" env:"REDACT_KEYS"`
+	}
+
+	// ContextAttributesFunc extracts span attributes from the context a span is started with.
+	ContextAttributesFunc func(ctx context.Context) []Attribute
+
+	enrichProcessor struct {
+		static      []attribute.KeyValue
+		fromContext ContextAttributesFunc
+	}
+
+	dropProcessor struct {
+		next     sdktrace.SpanProcessor
+		patterns []string
+	}
+
+	redactProcessor struct {
+		next     sdktrace.SpanProcessor
+		patterns []string
+	}
+
+	redactedSpan struct {
+		sdktrace.ReadOnlySpan
+		attrs  []attribute.KeyValue
+		events []sdktrace.Event
+	}
+)
+
+const RedactedValue = "[REDACTED]"
+
+var (
+	_ sdktrace.SpanProcessor = (*enrichProcessor)(nil)
+	_ sdktrace.SpanProcessor = (*dropProcessor)(nil)
+	_ sdktrace.SpanProcessor = (*redactProcessor)(nil)
+)
+
+// NewEnrichProcessor returns a processor that adds the static attributes and
+// the ones returned by fromContext (which may be nil) to every started span.
+func NewEnrichProcessor(static []Attribute, fromContext ContextAttributesFunc) sdktrace.SpanProcessor {
+	return &enrichProcessor{static: toOtelAttributes(static), fromContext: fromContext}
+}
+
+// NewDropProcessor returns a processor that forwards to next all the spans
+// except the ones whose names match the patterns.
+func NewDropProcessor(next sdktrace.SpanProcessor, patterns ...string) sdktrace.SpanProcessor {
+	return &dropProcessor{next: next, patterns: patterns}
+}
+
+// NewRedactProcessor returns a processor that forwards the spans to next with
+// the values of the span and event attributes matching the key patterns
+// redacted.
+func NewRedactProcessor(next sdktrace.SpanProcessor, keyPatterns ...string) sdktrace.SpanProcessor {
+	patterns := make([]string, 0, len(keyPatterns))
+	for _, p := range keyPatterns {
+		patterns = append(patterns, strings.ToLower(p))
+	}
+	return &redactProcessor{next: next, patterns: patterns}
+}
+
+func (c ProcessorsConfig) enrichProcessor() sdktrace.SpanProcessor {
+	if len(c.Attributes) == 0 && len(c.BaggageKeys) == 0 {
+		return nil
+	}
+
+	static := make([]Attribute, 0, len(c.Attributes))
+	for k, v := range c.Attributes {
+		static = append(static, New(k, v))
+	}
+
+	var fromContext ContextAttributesFunc
+	if len(c.BaggageKeys) > 0 {
+		fromContext = func(ctx context.Context) []Attribute {
+			return BaggageAttributes(ctx, c.BaggageKeys...)
+		}
+	}
+	return NewEnrichProcessor(static, fromContext)
+}
+
+// wrap layers the drop and redact processors in front of next, which is
+// either the export processor or one of Config.SpanProcessors.
+func (c ProcessorsConfig) wrap(next sdktrace.SpanProcessor) sdktrace.SpanProcessor {
+	if len(c.RedactKeys) > 0 {
+		next = NewRedactProcessor(next, c.RedactKeys...)
+	}
+	if len(c.DropSpanNames) > 0 {
+		next = NewDropProcessor(next, c.DropSpanNames...)
+	}
+	return next
+}
+
+func (p *enrichProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	s.SetAttributes(p.static...)
+	if p.fromContext != nil {
+		s.SetAttributes(toOtelAttributes(p.fromContext(parent))...)
+	}
+}
+
+func (p *enrichProcessor) OnEnd(sdktrace.ReadOnlySpan) {}
+
+func (p *enrichProcessor) Shutdown(context.Context) error { return nil }
+
+func (p *enrichProcessor) ForceFlush(context.Context) error { return nil }
+
+func (p *dropProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+func (p *dropProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	for _, pattern := range p.patterns {
+		if telemetry.MatchWildcard(pattern, s.Name()) {
+			return
+		}
+	}
+	p.next.OnEnd(s)
+}
+
+func (p *dropProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+func (p *dropProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+func (p *redactProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+func (p *redactProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	attrs, attrsRedacted := p.redact(s.Attributes())
+
+	events := s.Events()
+	var redactedEvents []sdktrace.Event
+	for i, event := range events {
+		eventAttrs, ok := p.redact(event.Attributes)
+		if !ok {
+			continue
+		}
+		if redactedEvents == nil {
+			redactedEvents = make([]sdktrace.Event, len(events))
+			copy(redactedEvents, events)
+		}
+		redactedEvents[i].Attributes = eventAttrs
+	}
+
+	if !attrsRedacted && redactedEvents == nil {
+		p.next.OnEnd(s)
+		return
+	}
+	if redactedEvents == nil {
+		redactedEvents = events
+	}
+	p.next.OnEnd(redactedSpan{ReadOnlySpan: s, attrs: attrs, events: redactedEvents})
+}
+
+// redact returns a copy of attrs with the matching values redacted, or attrs
+// and false when none matches.
+func (p *redactProcessor) redact(attrs []attribute.KeyValue) ([]attribute.KeyValue, bool) {
+	var redacted []attribute.KeyValue
+	for i, a := range attrs {
+		if !p.matches(string(a.Key)) {
+			continue
+		}
+		if redacted == nil {
+			redacted = make([]attribute.KeyValue, len(attrs))
+			copy(redacted, attrs)
+		}
+		redacted[i] = attribute.String(string(a.Key), RedactedValue)
+	}
+	if redacted == nil {
+		return attrs, false
+	}
+	return redacted, true
+}
+
+func (p *redactProcessor) matches(key string) bool {
+	key = strings.ToLower(key)
+	for _, pattern := range p.patterns {
+		if telemetry.MatchWildcard(pattern, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *redactProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+func (p *redactProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+func (s redactedSpan) Attributes() []attribute.KeyValue {
+	return s.attrs
+}
+
+func (s redactedSpan) Events() []sdktrace.Event {
+	return s.events
+}
+
+func toOtelAttributes(attrs []Attribute) []attribute.KeyValue {
+	otelAttrs := make([]attribute.KeyValue, 0, len(attrs))
+	for _, a := range attrs {
+		otelAttrs = append(otelAttrs, attribute.String(a.Key, a.Value))
+	}
+	return otelAttrs
+}
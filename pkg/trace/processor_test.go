@@ -0,0 +1,151 @@
+package trace
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	tracelib "go.opentelemetry.io/otel/trace"
+)
+
+func setupProcessorTest(t *testing.T, cfg ProcessorsConfig, custom ...sdktrace.SpanProcessor) (*InMemoryExporter, func()) {
+	t.Helper()
+	ctx := context.Background()
+	exporter := NewInMemoryExporter()
+	shutdown, err := SetupOTelSDK(ctx, Config{
+		ApplicationName: "test-app",
+		Exporter:        ExporterConfig{Type: ExporterInMemory, InMemory: exporter},
+		Processors:      cfg,
+		SpanProcessors:  custom,
+		DisableEnv:      true,
+	})
+	if err != nil {
+		t.Fatalf("failed to setup sdk: %v", err)
+	}
+	return exporter, func() { shutdown(context.Background()) }
+}
+
+func spanAttribute(span tracetest.SpanStub, key string) (string, bool) {
+	for _, a := range span.Attributes {
+		if string(a.Key) == key {
+			return a.Value.Emit(), true
+		}
+	}
+	return "", false
+}
+
+func TestEnrichProcessor(t *testing.T) {
+	exporter, cleanup := setupProcessorTest(t, ProcessorsConfig{
+		Attributes:  map[string]string{"team": "payments"},
+		BaggageKeys: []string{"tenant_id"},
+	})
+	defer cleanup()
+
+	ctx, err := SetBaggage(context.Background(), "tenant_id", "acme")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, span := otel.Tracer("test").Start(ctx, "span")
+	span.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	if v, _ := spanAttribute(spans[0], "team"); v != "payments" {
+		t.Errorf("expected team 'payments', got %q", v)
+	}
+	if v, _ := spanAttribute(spans[0], "tenant_id"); v != "acme" {
+		t.Errorf("expected tenant_id 'acme', got %q", v)
+	}
+}
+
+func TestDropProcessor(t *testing.T) {
+	exporter, cleanup := setupProcessorTest(t, ProcessorsConfig{
+		DropSpanNames: []string{"health*", "GET /metrics"},
+	})
+	defer cleanup()
+
+	for _, name := range []string{"healthcheck", "GET /metrics", "GET /users"} {
+		_, span := otel.Tracer("test").Start(context.Background(), name)
+		span.End()
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	if spans[0].Name != "GET /users" {
+		t.Errorf("expected span 'GET /users', got %s", spans[0].Name)
+	}
+}
+
+func TestRedactProcessor(t *testing.T) {
+	exporter, cleanup := setupProcessorTest(t, ProcessorsConfig{
+		RedactKeys: []string{"password", "*.token"},
+	})
+	defer cleanup()
+
+	_, span := otel.Tracer("test").Start(context.Background(), "span")
+	span.SetAttributes(
+		attribute.String("Password", "secret"),
+		attribute.String("auth.token", "abc"),
+		attribute.String("user.id", "42"),
+	)
+	span.AddEvent("login", tracelib.WithAttributes(attribute.String("password", "secret"), attribute.String("user.id", "42")))
+	span.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	tests := map[string]string{
+		"Password":   RedactedValue,
+		"auth.token": RedactedValue,
+		"user.id":    "42",
+	}
+	for key, expected := range tests {
+		if v, _ := spanAttribute(spans[0], key); v != expected {
+			t.Errorf("expected %s to be %q, got %q", key, expected, v)
+		}
+	}
+
+	eventAttrs := spans[0].Events[0].Attributes
+	if eventAttrs[0] != attribute.String("password", RedactedValue) || eventAttrs[1] != attribute.String("user.id", "42") {
+		t.Errorf("expected the event attributes to be redacted, got %v", eventAttrs)
+	}
+}
+
+func TestCustomSpanProcessor(t *testing.T) {
+	custom := tracetest.NewSpanRecorder()
+	exporter, cleanup := setupProcessorTest(t, ProcessorsConfig{
+		Attributes:    map[string]string{"team": "payments"},
+		RedactKeys:    []string{"password"},
+		DropSpanNames: []string{"health*"},
+	}, custom)
+	defer cleanup()
+
+	_, span := otel.Tracer("test").Start(context.Background(), "span")
+	span.SetAttributes(attribute.String("password", "secret"))
+	span.End()
+	_, dropped := otel.Tracer("test").Start(context.Background(), "healthcheck")
+	dropped.End()
+
+	ended := custom.Ended()
+	if len(ended) != 1 {
+		t.Fatalf("expected custom processor to receive 1 span, got %d", len(ended))
+	}
+	stub := tracetest.SpanStubFromReadOnlySpan(ended[0])
+	if v, ok := spanAttribute(stub, "team"); !ok || v != "payments" {
+		t.Errorf("expected custom processor to see enriched attributes, got %q", v)
+	}
+	if v, _ := spanAttribute(stub, "password"); v != RedactedValue {
+		t.Errorf("expected custom processor to see redacted attributes, got %q", v)
+	}
+	if len(exporter.GetSpans()) != 1 {
+		t.Errorf("expected exporter to receive 1 span, got %d", len(exporter.GetSpans()))
+	}
+}
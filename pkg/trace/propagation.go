@@ -0,0 +1,116 @@
+package trace
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/contrib/propagators/jaeger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+type (
+	Propagator string
+	Carrier    = propagation.TextMapCarrier
+
+	// MessageHeader is a message header keyed by bytes, as used by most
+	// message broker clients.
+	MessageHeader struct {
+		Key   []byte
+		Value []byte
+	}
+
+	// MessageCarrier adapts a slice of MessageHeader to a Carrier.
+	MessageCarrier struct {
+		headers *[]MessageHeader
+	}
+)
+
+const (
+	PropagatorTraceContext Propagator = "tracecontext"
+	PropagatorBaggage      Propagator = "baggage"
+	PropagatorB3           Propagator = "b3"
+	PropagatorB3Multi      Propagator = "b3multi"
+	PropagatorJaeger       Propagator = "jaeger"
+	PropagatorNone         Propagator = "none"
+)
+
+var _ Carrier = (*MessageCarrier)(nil)
+
+// Inject writes the trace context of ctx into the carrier using the global propagator.
+func Inject(ctx context.Context, carrier Carrier) {
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+}
+
+// Extract returns a copy of ctx carrying the trace context read from the carrier.
+func Extract(ctx context.Context, carrier Carrier) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
+
+func HeaderCarrier(h http.Header) Carrier {
+	return propagation.HeaderCarrier(h)
+}
+
+func MapCarrier(m map[string]string) Carrier {
+	return propagation.MapCarrier(m)
+}
+
+func NewMessageCarrier(headers *[]MessageHeader) *MessageCarrier {
+	return &MessageCarrier{headers: headers}
+}
+
+func (c *MessageCarrier) Get(key string) string {
+	for _, h := range *c.headers {
+		if string(h.Key) == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func (c *MessageCarrier) Set(key string, value string) {
+	for i, h := range *c.headers {
+		if string(h.Key) == key {
+			(*c.headers)[i].Value = []byte(value)
+			return
+		}
+	}
+	*c.headers = append(*c.headers, MessageHeader{Key: []byte(key), Value: []byte(value)})
+}
+
+func (c *MessageCarrier) Keys() []string {
+	keys := make([]string, 0, len(*c.headers))
+	for _, h := range *c.headers {
+		keys = append(keys, string(h.Key))
+	}
+	return keys
+}
+
+func newPropagator(propagators []Propagator) (propagation.TextMapPropagator, error) {
+	if len(propagators) == 0 {
+		propagators = []Propagator{PropagatorTraceContext, PropagatorBaggage}
+	}
+
+	result := make([]propagation.TextMapPropagator, 0, len(propagators))
+	for _, p := range propagators {
+		switch p {
+		case PropagatorTraceContext:
+			result = append(result, propagation.TraceContext{})
+		case PropagatorBaggage:
+			result = append(result, propagation.Baggage{})
+		case PropagatorB3:
+			result = append(result, b3.New(b3.WithInjectEncoding(b3.B3SingleHeader)))
+		case PropagatorB3Multi:
+			result = append(result, b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader)))
+		case PropagatorJaeger:
+			result = append(result, jaeger.Jaeger{})
+		case PropagatorNone:
+			// adds nothing, disabling propagation when used alone
+		default:
+			return nil, fmt.Errorf("unknown propagator %q", p)
+		}
+	}
+	return propagation.NewCompositeTextMapPropagator(result...), nil
+}
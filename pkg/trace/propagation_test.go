@@ -0,0 +1,199 @@
+package trace
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	tracelib "go.opentelemetry.io/otel/trace"
+)
+
+func setupPropagationTest(t *testing.T, propagators ...Propagator) (*InMemoryExporter, context.Context, func()) {
+	t.Helper()
+	ctx := context.Background()
+	exporter := NewInMemoryExporter()
+	shutdown, err := SetupOTelSDK(ctx, Config{
+		ApplicationName: "test-app",
+		Exporter:        ExporterConfig{Type: ExporterInMemory, InMemory: exporter},
+		Propagators:     propagators,
+	})
+	if err != nil {
+		t.Fatalf("failed to setup sdk: %v", err)
+	}
+
+	ctx, span := otel.Tracer("test").Start(ctx, "parent")
+	return exporter, ctx, func() {
+		span.End()
+		shutdown(context.Background())
+	}
+}
+
+func TestInjectExtract_Carriers(t *testing.T) {
+	_, ctx, cleanup := setupPropagationTest(t)
+	defer cleanup()
+	expected := tracelib.SpanContextFromContext(ctx).TraceID()
+
+	tests := []struct {
+		name    string
+		carrier Carrier
+	}{
+		{"http header", HeaderCarrier(http.Header{})},
+		{"map", MapCarrier(map[string]string{})},
+		{"message", NewMessageCarrier(&[]MessageHeader{})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Inject(ctx, tt.carrier)
+			if tt.carrier.Get("traceparent") == "" {
+				t.Fatal("expected traceparent to be injected")
+			}
+
+			extracted := tracelib.SpanContextFromContext(Extract(context.Background(), tt.carrier))
+			if extracted.TraceID() != expected {
+				t.Errorf("expected trace id %s, got %s", expected, extracted.TraceID())
+			}
+			if !extracted.IsRemote() {
+				t.Error("expected extracted span context to be remote")
+			}
+		})
+	}
+}
+
+func TestMessageCarrier(t *testing.T) {
+	headers := []MessageHeader{{Key: []byte("existing"), Value: []byte("1")}}
+	carrier := NewMessageCarrier(&headers)
+
+	carrier.Set("existing", "2")
+	carrier.Set("new", "3")
+
+	if len(headers) != 2 {
+		t.Fatalf("expected 2 headers, got %d", len(headers))
+	}
+	if carrier.Get("existing") != "2" {
+		t.Errorf("expected existing header to be replaced, got %s", carrier.Get("existing"))
+	}
+	if keys := carrier.Keys(); len(keys) != 2 || keys[1] != "new" {
+		t.Errorf("unexpected keys: %v", keys)
+	}
+}
+
+func TestNewPropagator(t *testing.T) {
+	tests := []struct {
+		name        string
+		propagators []Propagator
+		header      string
+	}{
+		{"default", nil, "traceparent"},
+		{"b3 single", []Propagator{PropagatorB3}, "b3"},
+		{"b3 multi", []Propagator{PropagatorB3Multi}, "x-b3-traceid"},
+		{"jaeger", []Propagator{PropagatorJaeger}, "uber-trace-id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ctx, cleanup := setupPropagationTest(t, tt.propagators...)
+			defer cleanup()
+
+			carrier := MapCarrier(map[string]string{})
+			Inject(ctx, carrier)
+			if carrier.Get(tt.header) == "" {
+				t.Errorf("expected header %s to be injected, got keys %v", tt.header, carrier.Keys())
+			}
+		})
+	}
+
+	if _, err := newPropagator([]Propagator{"unknown"}); err == nil {
+		t.Error("expected error for unknown propagator")
+	}
+}
+
+func TestRoundTripper(t *testing.T) {
+	exporter, ctx, cleanup := setupPropagationTest(t)
+	defer cleanup()
+
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewRoundTripper(nil)}
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res.Body.Close()
+
+	if received == "" {
+		t.Fatal("expected traceparent header on outbound request")
+	}
+	if req.Header.Get("traceparent") != "" {
+		t.Error("expected original request to be left untouched")
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name != "HTTP GET" || span.SpanKind != tracelib.SpanKindClient {
+		t.Errorf("unexpected span %s of kind %v", span.Name, span.SpanKind)
+	}
+	if span.Parent.SpanID() != tracelib.SpanContextFromContext(ctx).SpanID() {
+		t.Error("expected client span to be a child of the caller span")
+	}
+	if span.Status.Code != codes.Error {
+		t.Errorf("expected error status, got %v", span.Status.Code)
+	}
+}
+
+func TestRoundTripper_EndsWithBody(t *testing.T) {
+	exporter, ctx, cleanup := setupPropagationTest(t)
+	defer cleanup()
+
+	const delay = 50 * time.Millisecond
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("first"))
+		w.(http.Flusher).Flush()
+		time.Sleep(delay)
+		_, _ = w.Write([]byte("last"))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewRoundTripper(nil)}
+	for _, read := range []bool{true, false} {
+		exporter.Reset()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		res, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if spans := exporter.GetSpans(); len(spans) != 0 {
+			t.Fatalf("expected the span to last until the body is consumed, got %d spans", len(spans))
+		}
+
+		if read {
+			body, _ := io.ReadAll(res.Body)
+			if string(body) != "firstlast" {
+				t.Errorf("unexpected body %q", body)
+			}
+		}
+		res.Body.Close()
+
+		spans := exporter.GetSpans()
+		if len(spans) != 1 {
+			t.Fatalf("expected 1 span, got %d", len(spans))
+		}
+		if elapsed := spans[0].EndTime.Sub(spans[0].StartTime); read && elapsed < delay {
+			t.Errorf("expected the span to include the body, lasted %v", elapsed)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package trace
+
+import (
+	"io"
+	"net/http"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	tracelib "go.opentelemetry.io/otel/trace"
+)
+
+type (
+	// RoundTripper starts a client span for every outbound request and
+	// propagates the trace context through the request headers. The span
+	// ends when the response body is read to the end or closed.
+	RoundTripper struct {
+		base   http.RoundTripper
+		tracer tracelib.Tracer
+	}
+
+	// spanBody ends span once the body is read to the end, fails or is
+	// closed.
+	spanBody struct {
+		io.ReadCloser
+		span tracelib.Span
+		once sync.Once
+	}
+)
+
+var _ http.RoundTripper = (*RoundTripper)(nil)
+
+// NewRoundTripper wraps base, or http.DefaultTransport when base is nil. The
+// tracer is looked up once, from the global provider; a round tripper created
+// before SetupOTelSDK forwards its spans to the provider installed by it.
+func NewRoundTripper(base http.RoundTripper) *RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &RoundTripper{base: base, tracer: otel.Tracer(instrumentationName)}
+}
+
+func (rt *RoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	ctx, span := rt.tracer.Start(
+		r.Context(),
+		"HTTP "+r.Method,
+		tracelib.WithSpanKind(tracelib.SpanKindClient),
+		tracelib.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(r.Method),
+			semconv.URLFull(r.URL.Redacted()),
+			semconv.ServerAddress(r.URL.Hostname()),
+		),
+	)
+
+	r = r.Clone(ctx)
+	Inject(ctx, HeaderCarrier(r.Header))
+
+	res, err := rt.base.RoundTrip(r)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		span.End()
+		return res, err
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(res.StatusCode))
+	if res.StatusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(res.StatusCode))
+	}
+
+	// Bodies of switched protocols are also written to, so they are left
+	// unwrapped.
+	if _, writable := res.Body.(io.Writer); res.Body == nil || res.Body == http.NoBody || writable {
+		span.End()
+		return res, nil
+	}
+	res.Body = &spanBody{ReadCloser: res.Body, span: span}
+	return res, nil
+}
+
+func (b *spanBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	switch {
+	case err == io.EOF:
+		b.end()
+	case err != nil:
+		b.span.RecordError(err)
+		b.span.SetStatus(codes.Error, err.Error())
+		b.end()
+	}
+	return n, err
+}
+
+func (b *spanBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.end()
+	return err
+}
+
+func (b *spanBody) end() {
+	b.once.Do(func() {
+		b.span.End()
+	})
+}
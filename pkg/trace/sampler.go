@@ -0,0 +1,214 @@
+package trace
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bruno303/go-toolkit/pkg/telemetry"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	tracelib "go.opentelemetry.io/otel/trace"
+)
+
+type (
+	SamplerType string
+
+	SamplerConfig struct {
+		// Type selects the root sampler. Empty keeps the SDK default
+		// (parent based, always on).
+		Type SamplerType `yaml:"type" env:"TYPE"`
+		// Ratio is the fraction of traces sampled by SamplerRatio, from 0 to 1.
+		Ratio float64 `yaml:"ratio" env:"RATIO"`
+		// RatePerSecond is the number of traces per second allowed by
+		// SamplerRateLimited, whose spans with a parent follow its decision.
+		RatePerSecond float64 `yaml:"rate_per_second" env:"RATE_PER_SECOND"`
+		// ParentBased makes spans follow the sampling decision of their parent,
+		// applying the configured sampler only to root spans.
+		ParentBased bool `yaml:"parent_based" env:"PARENT_BASED"`
+		// Rules are evaluated in order before the sampler; the first match wins.
+		// In env vars, the rules are separated by ',' and decoded by
+		// SamplingRule.EnvDecode.
+		Rules []SamplingRule `yaml:"rules" env:"RULES"`
+	}
+
+	// SamplingRule matches spans by name and/or attribute. SpanName accepts
+	// '*' as a wildcard. A rule with Ratio 0 never samples the matched spans.
+	SamplingRule struct {
+		SpanName       string  `yaml:"span_name"`
+		AttributeKey   string  `yaml:"attribute_key"`
+		AttributeValue string  `yaml:"attribute_value"`
+		Ratio          float64 `yaml:"ratio"`
+	}
+)
+
+const (
+	SamplerAlwaysOn    SamplerType = "always_on"
+	SamplerAlwaysOff   SamplerType = "always_off"
+	SamplerRatio       SamplerType = "ratio"
+	SamplerRateLimited SamplerType = "rate_limited"
+)
+
+// EnvDecode decodes a rule from its fields, as key=value pairs separated by
+// ';' with the keys of the YAML form, such as "span_name=*/health;ratio=0".
+func (r *SamplingRule) EnvDecode(value string) error {
+	*r = SamplingRule{}
+	for _, field := range strings.Split(value, ";") {
+		key, v, ok := strings.Cut(strings.TrimSpace(field), "=")
+		if !ok {
+			return fmt.Errorf("invalid sampling rule field %q, expected key=value", field)
+		}
+		switch key {
+		case "span_name":
+			r.SpanName = v
+		case "attribute_key":
+			r.AttributeKey = v
+		case "attribute_value":
+			r.AttributeValue = v
+		case "ratio":
+			ratio, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return fmt.Errorf("invalid sampling rule ratio: %w", err)
+			}
+			r.Ratio = ratio
+		default:
+			return fmt.Errorf("unknown sampling rule field %q", key)
+		}
+	}
+	return nil
+}
+
+// isDefault reports whether the SDK default sampler should be kept.
+func (c SamplerConfig) isDefault() bool {
+	return c.Type == "" && !c.ParentBased && len(c.Rules) == 0
+}
+
+func newSampler(cfg SamplerConfig) (sdktrace.Sampler, error) {
+	var sampler sdktrace.Sampler
+	switch cfg.Type {
+	case "", SamplerAlwaysOn:
+		sampler = sdktrace.AlwaysSample()
+	case SamplerAlwaysOff:
+		sampler = sdktrace.NeverSample()
+	case SamplerRatio:
+		sampler = sdktrace.TraceIDRatioBased(cfg.Ratio)
+	case SamplerRateLimited:
+		if cfg.RatePerSecond <= 0 {
+			return nil, fmt.Errorf("RatePerSecond must be greater than zero, got %v", cfg.RatePerSecond)
+		}
+		sampler = newRateLimitedSampler(cfg.RatePerSecond)
+	default:
+		return nil, fmt.Errorf("unknown sampler type %q", cfg.Type)
+	}
+
+	if len(cfg.Rules) > 0 {
+		sampler = newRuleSampler(cfg.Rules, sampler)
+	}
+	if cfg.ParentBased {
+		sampler = sdktrace.ParentBased(sampler)
+	}
+	return sampler, nil
+}
+
+type rateLimitedSampler struct {
+	mu       sync.Mutex
+	rate     float64
+	capacity float64
+	tokens   float64
+	last     time.Time
+	now      func() time.Time
+}
+
+func newRateLimitedSampler(perSecond float64) *rateLimitedSampler {
+	capacity := max(perSecond, 1)
+	return &rateLimitedSampler{
+		rate:     perSecond,
+		capacity: capacity,
+		tokens:   capacity,
+		last:     time.Now(),
+		now:      time.Now,
+	}
+}
+
+// ShouldSample limits the traces, not the spans: a span with a valid parent
+// follows its sampling decision without taking a token, so sampled traces
+// are kept whole.
+func (s *rateLimitedSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	parent := tracelib.SpanContextFromContext(p.ParentContext)
+	decision := sdktrace.Drop
+	if parent.IsValid() {
+		if parent.IsSampled() {
+			decision = sdktrace.RecordAndSample
+		}
+	} else if s.take() {
+		decision = sdktrace.RecordAndSample
+	}
+	return sdktrace.SamplingResult{
+		Decision:   decision,
+		Tracestate: parent.TraceState(),
+	}
+}
+
+func (s *rateLimitedSampler) take() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.tokens = min(s.capacity, s.tokens+now.Sub(s.last).Seconds()*s.rate)
+	s.last = now
+	if s.tokens < 1 {
+		return false
+	}
+	s.tokens--
+	return true
+}
+
+func (s *rateLimitedSampler) Description() string {
+	return fmt.Sprintf("RateLimited{%v}", s.rate)
+}
+
+type ruleSampler struct {
+	rules    []SamplingRule
+	samplers []sdktrace.Sampler
+	fallback sdktrace.Sampler
+}
+
+func newRuleSampler(rules []SamplingRule, fallback sdktrace.Sampler) *ruleSampler {
+	samplers := make([]sdktrace.Sampler, 0, len(rules))
+	for _, r := range rules {
+		samplers = append(samplers, sdktrace.TraceIDRatioBased(r.Ratio))
+	}
+	return &ruleSampler{rules: rules, samplers: samplers, fallback: fallback}
+}
+
+func (s *ruleSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	for i, r := range s.rules {
+		if r.matches(p) {
+			return s.samplers[i].ShouldSample(p)
+		}
+	}
+	return s.fallback.ShouldSample(p)
+}
+
+func (s *ruleSampler) Description() string {
+	return fmt.Sprintf("RuleBased{rules:%d,fallback:%s}", len(s.rules), s.fallback.Description())
+}
+
+func (r SamplingRule) matches(p sdktrace.SamplingParameters) bool {
+	if r.SpanName == "" && r.AttributeKey == "" {
+		return false
+	}
+	if r.SpanName != "" && !telemetry.MatchWildcard(r.SpanName, p.Name) {
+		return false
+	}
+	if r.AttributeKey == "" {
+		return true
+	}
+	for _, attr := range p.Attributes {
+		if string(attr.Key) == r.AttributeKey {
+			return r.AttributeValue == "" || attr.Value.Emit() == r.AttributeValue
+		}
+	}
+	return false
+}
@@ -0,0 +1,205 @@
+package trace
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/sethvargo/go-envconfig"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	tracelib "go.opentelemetry.io/otel/trace"
+	"gopkg.in/yaml.v3"
+)
+
+func samplingParams(name string, attrs ...attribute.KeyValue) sdktrace.SamplingParameters {
+	return sdktrace.SamplingParameters{
+		ParentContext: context.Background(),
+		TraceID:       tracelib.TraceID{0x01},
+		Name:          name,
+		Attributes:    attrs,
+	}
+}
+
+func TestNewSampler_Types(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      SamplerConfig
+		expected sdktrace.SamplingDecision
+	}{
+		{"always on", SamplerConfig{Type: SamplerAlwaysOn}, sdktrace.RecordAndSample},
+		{"always off", SamplerConfig{Type: SamplerAlwaysOff}, sdktrace.Drop},
+		{"ratio zero", SamplerConfig{Type: SamplerRatio, Ratio: 0}, sdktrace.Drop},
+		{"ratio one", SamplerConfig{Type: SamplerRatio, Ratio: 1}, sdktrace.RecordAndSample},
+		{"rate limited", SamplerConfig{Type: SamplerRateLimited, RatePerSecond: 10}, sdktrace.RecordAndSample},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sampler, err := newSampler(tt.cfg)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := sampler.ShouldSample(samplingParams("span")).Decision; got != tt.expected {
+				t.Errorf("expected decision %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestNewSampler_Errors(t *testing.T) {
+	if _, err := newSampler(SamplerConfig{Type: "unknown"}); err == nil {
+		t.Error("expected error for unknown sampler type")
+	}
+	if _, err := newSampler(SamplerConfig{Type: SamplerRateLimited}); err == nil {
+		t.Error("expected error for rate limited sampler without rate")
+	}
+}
+
+func TestRateLimitedSampler(t *testing.T) {
+	now := time.Now()
+	sampler := newRateLimitedSampler(2)
+	sampler.last = now
+	sampler.now = func() time.Time { return now }
+
+	sampled := 0
+	for range 5 {
+		if sampler.ShouldSample(samplingParams("span")).Decision == sdktrace.RecordAndSample {
+			sampled++
+		}
+	}
+	if sampled != 2 {
+		t.Errorf("expected 2 sampled spans, got %d", sampled)
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	if sampler.ShouldSample(samplingParams("span")).Decision != sdktrace.RecordAndSample {
+		t.Error("expected a token to be available after refill")
+	}
+}
+
+func TestRateLimitedSampler_FollowsParent(t *testing.T) {
+	now := time.Now()
+	sampler := newRateLimitedSampler(1)
+	sampler.last = now
+	sampler.now = func() time.Time { return now }
+
+	root := sampler.ShouldSample(samplingParams("root"))
+	if root.Decision != sdktrace.RecordAndSample {
+		t.Fatal("expected the root span to be sampled")
+	}
+
+	parent := tracelib.NewSpanContext(tracelib.SpanContextConfig{
+		TraceID:    tracelib.TraceID{1},
+		SpanID:     tracelib.SpanID{1},
+		TraceFlags: tracelib.FlagsSampled,
+	})
+	child := samplingParams("child")
+	child.ParentContext = tracelib.ContextWithSpanContext(context.Background(), parent)
+	for range 3 {
+		if sampler.ShouldSample(child).Decision != sdktrace.RecordAndSample {
+			t.Error("expected the children of a sampled span to be sampled")
+		}
+	}
+
+	unsampled := samplingParams("child")
+	unsampled.ParentContext = tracelib.ContextWithSpanContext(context.Background(), parent.WithTraceFlags(0))
+	if sampler.ShouldSample(unsampled).Decision != sdktrace.Drop {
+		t.Error("expected the children of a dropped span to be dropped")
+	}
+
+	if sampler.ShouldSample(samplingParams("root")).Decision != sdktrace.Drop {
+		t.Error("expected the children to take no token")
+	}
+}
+
+func TestRuleSampler(t *testing.T) {
+	sampler, err := newSampler(SamplerConfig{
+		Type: SamplerAlwaysOn,
+		Rules: []SamplingRule{
+			{SpanName: "*/health"},
+			{AttributeKey: "http.route", AttributeValue: "/metrics"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		params   sdktrace.SamplingParameters
+		expected sdktrace.SamplingDecision
+	}{
+		{"health by name", samplingParams("GET /health"), sdktrace.Drop},
+		{"metrics by attribute", samplingParams("GET", attribute.String("http.route", "/metrics")), sdktrace.Drop},
+		{"other attribute value", samplingParams("GET", attribute.String("http.route", "/users")), sdktrace.RecordAndSample},
+		{"fallback", samplingParams("GET /users"), sdktrace.RecordAndSample},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sampler.ShouldSample(tt.params).Decision; got != tt.expected {
+				t.Errorf("expected decision %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSamplerConfig_Load(t *testing.T) {
+	var cfg Config
+	content := `
+sampler:
+  type: ratio
+  ratio: 0.25
+  parent_based: true
+  rules:
+    - span_name: /health
+      ratio: 0
+`
+	if err := yaml.Unmarshal([]byte(content), &cfg); err != nil {
+		t.Fatalf("failed to decode yaml: %v", err)
+	}
+
+	env := map[string]string{
+		"SAMPLER_RATIO": "0.5",
+		"SAMPLER_RULES": "span_name=*/health;ratio=0, attribute_key=http.route;attribute_value=/metrics;ratio=0.1",
+	}
+	err := envconfig.ProcessWith(context.Background(), &envconfig.Config{
+		Target:           &cfg,
+		Lookuper:         envconfig.MapLookuper(env),
+		DefaultOverwrite: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to process envs: %v", err)
+	}
+
+	if cfg.Sampler.Type != SamplerRatio {
+		t.Errorf("expected sampler type ratio, got %s", cfg.Sampler.Type)
+	}
+	if cfg.Sampler.Ratio != 0.5 {
+		t.Errorf("expected ratio 0.5 from env, got %v", cfg.Sampler.Ratio)
+	}
+	if !cfg.Sampler.ParentBased {
+		t.Error("expected parent based sampler")
+	}
+	expected := []SamplingRule{
+		{SpanName: "*/health"},
+		{AttributeKey: "http.route", AttributeValue: "/metrics", Ratio: 0.1},
+	}
+	if !slices.Equal(cfg.Sampler.Rules, expected) {
+		t.Errorf("expected rules %+v from env, got %+v", expected, cfg.Sampler.Rules)
+	}
+	if cfg.Exporter.InMemory != nil {
+		t.Error("expected in-memory exporter to stay nil")
+	}
+}
+
+func TestSamplingRule_EnvDecodeErrors(t *testing.T) {
+	for _, value := range []string{"span_name", "ratio=half", "name=/health"} {
+		var rule SamplingRule
+		if err := rule.EnvDecode(value); err == nil {
+			t.Errorf("expected an error for %q", value)
+		}
+	}
+}
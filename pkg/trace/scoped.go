@@ -0,0 +1,113 @@
+package trace
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	tracelib "go.opentelemetry.io/otel/trace"
+)
+
+type (
+	// ScopedTracer is a Tracer bound to an instrumentation scope. Unlike
+	// OtelTracerAdapter, it looks up the OTel tracer once, on creation.
+	ScopedTracer struct {
+		adapter OtelTracerAdapter
+		tracer  tracelib.Tracer
+	}
+
+	TracerOption func(*tracerOptions)
+
+	tracerOptions struct {
+		version   string
+		schemaURL string
+		provider  tracelib.TracerProvider
+		strict    bool
+	}
+)
+
+var _ Tracer = ScopedTracer{}
+
+// WithVersion sets the instrumentation scope version.
+func WithVersion(version string) TracerOption {
+	return func(o *tracerOptions) {
+		o.version = version
+	}
+}
+
+// WithSchemaURL sets the schema URL of the instrumentation scope.
+func WithSchemaURL(schemaURL string) TracerOption {
+	return func(o *tracerOptions) {
+		o.schemaURL = schemaURL
+	}
+}
+
+// WithTracerProvider uses provider instead of the global OTel tracer provider.
+func WithTracerProvider(provider tracelib.TracerProvider) TracerOption {
+	return func(o *tracerOptions) {
+		o.provider = provider
+	}
+}
+
+// WithStrict behaves as OtelTracerAdapterOpts.Strict.
+func WithStrict(strict bool) TracerOption {
+	return func(o *tracerOptions) {
+		o.strict = strict
+	}
+}
+
+// NewTracer returns a Tracer for the instrumentation scope. When no provider is
+// given, the global one is used; a tracer created before SetupOTelSDK forwards
+// its spans to the provider installed by it.
+func NewTracer(scope string, opts ...TracerOption) ScopedTracer {
+	o := tracerOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.provider == nil {
+		o.provider = otel.GetTracerProvider()
+	}
+
+	tracerOpts := make([]tracelib.TracerOption, 0, 2)
+	if o.version != "" {
+		tracerOpts = append(tracerOpts, tracelib.WithInstrumentationVersion(o.version))
+	}
+	if o.schemaURL != "" {
+		tracerOpts = append(tracerOpts, tracelib.WithSchemaURL(o.schemaURL))
+	}
+
+	return ScopedTracer{
+		adapter: OtelTracerAdapter{strict: o.strict},
+		tracer:  o.provider.Tracer(scope, tracerOpts...),
+	}
+}
+
+func (t ScopedTracer) Trace(ctx context.Context, cfg *TraceConfig, cb TraceCallback) (any, error) {
+	resolved, err := t.adapter.resolveConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, span := t.tracer.Start(ctx, resolved.TraceName+"."+resolved.SpanName)
+	defer span.End()
+	res, err := cb(ctx)
+	if err != nil {
+		span.RecordError(err)
+	}
+	return res, err
+}
+
+func (t ScopedTracer) ExtractTraceIds(ctx context.Context) TraceIDs {
+	return t.adapter.ExtractTraceIds(ctx)
+}
+
+func (t ScopedTracer) InjectAttributes(ctx context.Context, attrs ...Attribute) {
+	t.adapter.InjectAttributes(ctx, attrs...)
+}
+
+func (t ScopedTracer) InjectError(ctx context.Context, err error) {
+	t.adapter.InjectError(ctx, err)
+}
+
+func (t ScopedTracer) isStrict() bool {
+	return t.adapter.strict
+}
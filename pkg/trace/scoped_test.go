@@ -0,0 +1,87 @@
+package trace
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	sdktracetest "go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestNewTracer(t *testing.T) {
+	recorder := sdktracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracer := NewTracer("my-lib", WithVersion("1.2.3"), WithTracerProvider(provider))
+
+	_, err := tracer.Trace(context.Background(), NameConfig("repo", "find"), func(ctx context.Context) (any, error) {
+		if !tracer.ExtractTraceIds(ctx).IsValid {
+			t.Error("expected a valid span in the callback context")
+		}
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	if spans[0].Name() != "repo.find" {
+		t.Errorf("expected span name 'repo.find', got %s", spans[0].Name())
+	}
+	scope := spans[0].InstrumentationScope()
+	if scope.Name != "my-lib" || scope.Version != "1.2.3" {
+		t.Errorf("unexpected instrumentation scope %+v", scope)
+	}
+}
+
+func TestNewTracer_Strict(t *testing.T) {
+	tracer := NewTracer("my-lib", WithStrict(true))
+	_, err := tracer.Trace(context.Background(), nil, func(ctx context.Context) (any, error) {
+		return nil, nil
+	})
+	if err == nil {
+		t.Error("expected error for missing names in strict mode")
+	}
+}
+
+func setupBenchmarkProvider(b *testing.B) {
+	b.Helper()
+	previous := otel.GetTracerProvider()
+	provider := sdktrace.NewTracerProvider()
+	otel.SetTracerProvider(provider)
+	b.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		_ = provider.Shutdown(context.Background())
+	})
+}
+
+func noopCallback(ctx context.Context) (any, error) {
+	return nil, nil
+}
+
+func BenchmarkOtelTracerAdapter_Trace(b *testing.B) {
+	setupBenchmarkProvider(b)
+	tracer := NewOtelTracerAdapter()
+	ctx := context.Background()
+	cfg := NameConfig("bench", "span")
+
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = tracer.Trace(ctx, cfg, noopCallback)
+	}
+}
+
+func BenchmarkScopedTracer_Trace(b *testing.B) {
+	setupBenchmarkProvider(b)
+	tracer := NewTracer("bench")
+	ctx := context.Background()
+	cfg := NameConfig("bench", "span")
+
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = tracer.Trace(ctx, cfg, noopCallback)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"sync/atomic"
 )
 
 type (
@@ -28,11 +29,23 @@ type (
 	TraceCallback func(ctx context.Context) (any, error)
 )
 
+type tracerHolder struct {
+	tracer Tracer
+}
+
+const instrumentationName = "github.com/bruno303/go-toolkit/pkg/trace"
+
 var (
-	tracer Tracer = NewNoOpTracer()
+	ErrInvalidTraceConfig = errors.New("invalid trace config")
+
+	tracer atomic.Pointer[tracerHolder]
 	once   sync.Once
 )
 
+func init() {
+	tracer.Store(&tracerHolder{tracer: NewNoOpTracer()})
+}
+
 const (
 	_ TraceKind = iota
 	TraceKindServer
@@ -41,29 +54,40 @@ const (
 )
 
 func GetTracer() Tracer {
-	return tracer
+	return tracer.Load().tracer
 }
 
+// SetTracer sets the global tracer. Only the first call has effect; use
+// ReplaceTracer to override it afterwards.
 func SetTracer(t Tracer) {
 	once.Do(func() {
-		tracer = t
+		tracer.Store(&tracerHolder{tracer: t})
 	})
 }
 
+// ReplaceTracer replaces the global tracer, regardless of previous SetTracer
+// calls, and returns a function that restores the previous one.
+func ReplaceTracer(t Tracer) (restore func()) {
+	previous := tracer.Swap(&tracerHolder{tracer: t})
+	return func() {
+		tracer.Store(previous)
+	}
+}
+
 func Trace(ctx context.Context, cfg *TraceConfig, cb TraceCallback) (any, error) {
-	return tracer.Trace(ctx, cfg, cb)
+	return GetTracer().Trace(ctx, cfg, cb)
 }
 
 func ExtractTraceIds(ctx context.Context) TraceIDs {
-	return tracer.ExtractTraceIds(ctx)
+	return GetTracer().ExtractTraceIds(ctx)
 }
 
 func InjectAttributes(ctx context.Context, attrs ...Attribute) {
-	tracer.InjectAttributes(ctx, attrs...)
+	GetTracer().InjectAttributes(ctx, attrs...)
 }
 
 func InjectError(ctx context.Context, err error) {
-	tracer.InjectError(ctx, err)
+	GetTracer().InjectError(ctx, err)
 }
 
 func NameConfig(traceName string, spanName string) *TraceConfig {
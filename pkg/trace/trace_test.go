@@ -0,0 +1,40 @@
+package trace
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestReplaceTracer(t *testing.T) {
+	original := GetTracer()
+
+	replacement := NewOtelTracerAdapter()
+	restore := ReplaceTracer(replacement)
+	if GetTracer() != replacement {
+		t.Errorf("expected replaced tracer, got %T", GetTracer())
+	}
+
+	restore()
+	if GetTracer() != original {
+		t.Errorf("expected original tracer to be restored, got %T", GetTracer())
+	}
+}
+
+func TestReplaceTracer_Concurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			restore := ReplaceTracer(NewOtelTracerAdapter())
+			restore()
+		}()
+		go func() {
+			defer wg.Done()
+			if GetTracer() == nil {
+				t.Error("expected tracer to not be nil")
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,84 @@
+package tracetest
+
+import (
+	"slices"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+)
+
+// AssertSpanNames checks that the recorded spans have exactly the given names,
+// in the order they ended.
+func AssertSpanNames(t testing.TB, r *Recorder, names ...string) {
+	t.Helper()
+	got := make([]string, 0, len(names))
+	for _, s := range r.Spans() {
+		got = append(got, s.Name())
+	}
+	if !slices.Equal(got, names) {
+		t.Errorf("expected spans %v, got %v", names, got)
+	}
+}
+
+// AssertSpan checks that a span with the given name was recorded and returns it.
+func AssertSpan(t testing.TB, r *Recorder, name string) Span {
+	t.Helper()
+	span, ok := r.Span(name)
+	if !ok {
+		t.Fatalf("expected span %s to be recorded", name)
+	}
+	return span
+}
+
+// AssertAttribute checks that span has the attribute key with the given value,
+// compared by its string representation.
+func AssertAttribute(t testing.TB, span Span, key string, value string) {
+	t.Helper()
+	for _, a := range span.Attributes() {
+		if string(a.Key) == key {
+			if a.Value.Emit() != value {
+				t.Errorf("expected attribute %s of span %s to be %s, got %s", key, span.Name(), value, a.Value.Emit())
+			}
+			return
+		}
+	}
+	t.Errorf("expected span %s to have attribute %s", span.Name(), key)
+}
+
+// AssertParent checks that child is a direct child of parent.
+func AssertParent(t testing.TB, child Span, parent Span) {
+	t.Helper()
+	if child.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("expected span %s to be a child of %s", child.Name(), parent.Name())
+	}
+	if child.SpanContext().TraceID() != parent.SpanContext().TraceID() {
+		t.Errorf("expected span %s to be in the same trace as %s", child.Name(), parent.Name())
+	}
+}
+
+// AssertRoot checks that span has no parent.
+func AssertRoot(t testing.TB, span Span) {
+	t.Helper()
+	if span.Parent().IsValid() {
+		t.Errorf("expected span %s to be a root span", span.Name())
+	}
+}
+
+// AssertStatus checks the status code of span.
+func AssertStatus(t testing.TB, span Span, code codes.Code) {
+	t.Helper()
+	if span.Status().Code != code {
+		t.Errorf("expected status of span %s to be %v, got %v", span.Name(), code, span.Status().Code)
+	}
+}
+
+// AssertError checks that an error event was recorded on span.
+func AssertError(t testing.TB, span Span) {
+	t.Helper()
+	for _, e := range span.Events() {
+		if e.Name == "exception" {
+			return
+		}
+	}
+	t.Errorf("expected span %s to have a recorded error", span.Name())
+}
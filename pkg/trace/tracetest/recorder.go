@@ -0,0 +1,64 @@
+// Package tracetest provides an in-memory span recorder and assertions to
+// verify the spans created through the trace package.
+package tracetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bruno303/go-toolkit/pkg/trace"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	sdktracetest "go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type (
+	Span = sdktrace.ReadOnlySpan
+
+	Recorder struct {
+		recorder *sdktracetest.SpanRecorder
+		provider *sdktrace.TracerProvider
+	}
+)
+
+// NewRecorder installs a recording OTel tracer provider and an OtelTracerAdapter
+// as the global tracer. Both are restored when the test finishes, so tests
+// using a Recorder must not run in parallel.
+func NewRecorder(t testing.TB) *Recorder {
+	t.Helper()
+
+	recorder := sdktracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	previousProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	restoreTracer := trace.ReplaceTracer(trace.NewOtelTracerAdapter())
+
+	t.Cleanup(func() {
+		restoreTracer()
+		otel.SetTracerProvider(previousProvider)
+		_ = provider.Shutdown(context.Background())
+	})
+
+	return &Recorder{recorder: recorder, provider: provider}
+}
+
+// Spans returns the ended spans in the order they ended.
+func (r *Recorder) Spans() []Span {
+	return r.recorder.Ended()
+}
+
+// Span returns the first ended span with the given name.
+func (r *Recorder) Span(name string) (Span, bool) {
+	for _, s := range r.recorder.Ended() {
+		if s.Name() == name {
+			return s, true
+		}
+	}
+	return nil, false
+}
+
+// Reset discards the spans recorded so far.
+func (r *Recorder) Reset() {
+	r.recorder.Reset()
+}
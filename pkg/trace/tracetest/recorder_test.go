@@ -0,0 +1,54 @@
+package tracetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bruno303/go-toolkit/pkg/trace"
+)
+
+func TestRecorder(t *testing.T) {
+	rec := NewRecorder(t)
+	ctx := context.Background()
+	expectedErr := errors.New("failure")
+
+	_, err := trace.Trace(ctx, trace.NameConfig("service", "parent"), func(ctx context.Context) (any, error) {
+		trace.InjectAttributes(ctx, trace.New("user.id", "42"))
+		return trace.Trace(ctx, trace.NameConfig("repository", "child"), func(ctx context.Context) (any, error) {
+			return nil, expectedErr
+		})
+	})
+	if !errors.Is(err, expectedErr) {
+		t.Fatalf("expected error %v, got %v", expectedErr, err)
+	}
+
+	AssertSpanNames(t, rec, "repository.child", "service.parent")
+	parent := AssertSpan(t, rec, "service.parent")
+	child := AssertSpan(t, rec, "repository.child")
+
+	AssertRoot(t, parent)
+	AssertParent(t, child, parent)
+	AssertAttribute(t, parent, "user.id", "42")
+	AssertError(t, child)
+
+	rec.Reset()
+	if len(rec.Spans()) != 0 {
+		t.Errorf("expected no spans after reset, got %d", len(rec.Spans()))
+	}
+}
+
+func TestRecorder_RestoresTracer(t *testing.T) {
+	original := trace.GetTracer()
+
+	t.Run("recording", func(t *testing.T) {
+		NewRecorder(t)
+		if _, ok := trace.GetTracer().(trace.OtelTracerAdapter); !ok {
+			t.Errorf("expected otel tracer adapter, got %T", trace.GetTracer())
+		}
+	})
+
+	if trace.GetTracer() != original {
+		t.Errorf("expected tracer to be restored, got %T", trace.GetTracer())
+	}
+}